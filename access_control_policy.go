@@ -0,0 +1,229 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/pkg/utils"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+const (
+	accessControlPolicyBasePath   = "/access_control_policies"
+	accessControlPolicyListPath   = accessControlPolicyBasePath + "/list"
+	accessControlPolicySinglePath = accessControlPolicyBasePath + "/%s"
+)
+
+// AccessControlPolicyClient is a client for the access control policy API.
+type AccessControlPolicyClient struct {
+	client *Client
+}
+
+// Get retrieves an access control policy by its UUID if the input can be parsed as an uuid, otherwise it
+// retrieves an access control policy by its name
+func (c *AccessControlPolicyClient) Get(ctx context.Context, idOrName string) (*schema.AccessControlPolicyIntent, error) {
+	if utils.IsValidUUID(idOrName) {
+		return c.GetByUUID(ctx, idOrName)
+	}
+	return c.GetByName(ctx, idOrName)
+}
+
+// GetByUUID retrieves an access control policy by its UUID
+func (c *AccessControlPolicyClient) GetByUUID(ctx context.Context, uuid string) (*schema.AccessControlPolicyIntent, error) {
+	response := new(schema.AccessControlPolicyIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(accessControlPolicySinglePath, uuid), http.MethodGet, nil, response)
+	return response, err
+}
+
+// GetByName retrieves an access control policy by its name
+func (c *AccessControlPolicyClient) GetByName(ctx context.Context, name string) (*schema.AccessControlPolicyIntent, error) {
+	list, err := c.List(ctx, &schema.DSMetadata{Filter: fmt.Sprintf("name==%s", name)})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Entities) == 0 {
+		return nil, fmt.Errorf("access control policy not found: %s", name)
+	}
+	return list.Entities[0], err
+}
+
+// List returns a list of access control policies
+func (c *AccessControlPolicyClient) List(ctx context.Context, opts *schema.DSMetadata) (*schema.AccessControlPolicyListIntent, error) {
+	response := new(schema.AccessControlPolicyListIntent)
+	err := c.client.requestHelper(ctx, accessControlPolicyListPath, http.MethodPost, opts, response)
+	return response, err
+
+}
+
+// All returns all access control policies
+func (c *AccessControlPolicyClient) All(ctx context.Context) (*schema.AccessControlPolicyListIntent, error) {
+	return c.List(ctx, &schema.DSMetadata{Length: utils.Int64Ptr(itemsPerPage), Offset: utils.Int64Ptr(0)})
+}
+
+// Create creates an access control policy
+func (c *AccessControlPolicyClient) Create(ctx context.Context, createRequest *schema.AccessControlPolicyIntent) (*schema.AccessControlPolicyIntent, error) {
+	response := new(schema.AccessControlPolicyIntent)
+	err := c.client.requestHelper(ctx, accessControlPolicyBasePath, http.MethodPost, createRequest, response)
+	return response, err
+}
+
+// Update an access control policy
+func (c *AccessControlPolicyClient) Update(ctx context.Context, accessControlPolicy *schema.AccessControlPolicyIntent) (*schema.AccessControlPolicyIntent, error) {
+	response := new(schema.AccessControlPolicyIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(accessControlPolicySinglePath, accessControlPolicy.Metadata.UUID), http.MethodPut, accessControlPolicy.ToUpdateRequest(), response)
+	return response, err
+}
+
+// UpdateWith fetches the latest access control policy, applies mutate to it and updates it. On a
+// spec_version conflict the access control policy is fetched again and mutate is re-applied.
+// It returns the updated access control policy and the uuid of the update task.
+func (c *AccessControlPolicyClient) UpdateWith(ctx context.Context, uuid string, mutate func(*schema.AccessControlPolicyIntent) error) (*schema.AccessControlPolicyIntent, string, error) {
+	var response *schema.AccessControlPolicyIntent
+	err := c.client.retryOnConflict(ctx, func() error {
+		accessControlPolicy, err := c.GetByUUID(ctx, uuid)
+		if err != nil {
+			return err
+		}
+		if err = mutate(accessControlPolicy); err != nil {
+			return err
+		}
+		response, err = c.Update(ctx, accessControlPolicy)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	var taskUUID string
+	if response.Status != nil {
+		taskUUID = response.Status.ExecutionContext.GetTaskUUID()
+	}
+	return response, taskUUID, nil
+}
+
+// Delete deletes an access control policy
+func (c *AccessControlPolicyClient) Delete(ctx context.Context, uuid string) error {
+	return c.client.requestHelper(ctx, fmt.Sprintf(accessControlPolicySinglePath, uuid), http.MethodDelete, nil, nil)
+}
+
+// GrantRoleOnProject grants the role to the user group on all entities of the project. The
+// group is added to the project if it is not a member yet. An existing policy for the role and
+// project is extended with the group, otherwise a new policy is created.
+func (c *AccessControlPolicyClient) GrantRoleOnProject(ctx context.Context, roleUUID, userGroupUUID, projectUUID string) (*schema.AccessControlPolicyIntent, error) {
+	userGroup := &schema.Reference{Kind: "user_group", UUID: userGroupUUID}
+	project, err := c.client.Project.AddUserGroup(ctx, projectUUID, userGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	if project.Spec == nil {
+		return nil, fmt.Errorf("project %s has no spec", projectUUID)
+	}
+
+	policies, err := c.listAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range policies {
+		if !grantsRoleOnProject(policy, roleUUID, projectUUID) {
+			continue
+		}
+		_, taskUUID, err := c.UpdateWith(ctx, policy.Metadata.UUID, func(policy *schema.AccessControlPolicyIntent) error {
+			if policy.Spec == nil || policy.Spec.Resources == nil {
+				return fmt.Errorf("access control policy %s has no spec resources", policy.Metadata.UUID)
+			}
+			policy.Spec.Resources.UserGroupReferenceList = addReference(policy.Spec.Resources.UserGroupReferenceList, userGroup)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return c.waitAndGet(ctx, policy.Metadata.UUID, taskUUID)
+	}
+
+	role, err := c.client.Role.GetByUUID(ctx, roleUUID)
+	if err != nil {
+		return nil, err
+	}
+	if role.Spec == nil {
+		return nil, fmt.Errorf("role %s has no spec", roleUUID)
+	}
+	createRequest := &schema.AccessControlPolicyIntent{
+		Metadata: &schema.Metadata{Kind: "access_control_policy"},
+		Spec: &schema.AccessControlPolicy{
+			Name: fmt.Sprintf("%s %s", project.Spec.Name, role.Spec.Name),
+			Resources: &schema.AccessControlPolicyResources{
+				RoleReference:          &schema.Reference{Kind: "role", UUID: roleUUID},
+				UserGroupReferenceList: []*schema.Reference{userGroup},
+				FilterList: &schema.FilterList{
+					ContextList: []*schema.FilterContext{{
+						ScopeFilterExpressionList: []*schema.ScopeFilterExpression{{
+							LeftHandSide:  schema.ScopeProject,
+							Operator:      schema.FilterOperatorIn,
+							RightHandSide: &schema.FilterRightHandSide{UUIDList: []string{projectUUID}},
+						}},
+						EntityFilterExpressionList: []*schema.EntityFilterExpression{{
+							LeftHandSide:  &schema.EntityFilterLeftHandSide{EntityType: schema.EntityTypeAll},
+							Operator:      schema.FilterOperatorIn,
+							RightHandSide: &schema.FilterRightHandSide{Collection: schema.CollectionAll},
+						}},
+					}},
+				},
+			},
+		},
+	}
+	policy, err := c.Create(ctx, createRequest)
+	if err != nil {
+		return nil, err
+	}
+	var taskUUID string
+	if policy.Status != nil {
+		taskUUID = policy.Status.ExecutionContext.GetTaskUUID()
+	}
+	return c.waitAndGet(ctx, policy.Metadata.UUID, taskUUID)
+}
+
+// listAll pages through all access control policies
+func (c *AccessControlPolicyClient) listAll(ctx context.Context) ([]*schema.AccessControlPolicyIntent, error) {
+	var policies []*schema.AccessControlPolicyIntent
+	var offset int64
+	for {
+		list, err := c.List(ctx, &schema.DSMetadata{Length: utils.Int64Ptr(itemsPerPage), Offset: utils.Int64Ptr(offset)})
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, list.Entities...)
+		offset += int64(len(list.Entities))
+		if len(list.Entities) == 0 || list.Metadata == nil || offset >= list.Metadata.TotalMatches {
+			return policies, nil
+		}
+	}
+}
+
+func (c *AccessControlPolicyClient) waitAndGet(ctx context.Context, uuid, taskUUID string) (*schema.AccessControlPolicyIntent, error) {
+	if _, err := c.client.Task.Wait(ctx, taskUUID); err != nil {
+		return nil, err
+	}
+	return c.GetByUUID(ctx, uuid)
+}
+
+// grantsRoleOnProject reports whether the policy grants the role scoped to the project
+func grantsRoleOnProject(policy *schema.AccessControlPolicyIntent, roleUUID, projectUUID string) bool {
+	if policy.Spec == nil || policy.Spec.Resources == nil || policy.Spec.Resources.RoleReference == nil ||
+		policy.Spec.Resources.RoleReference.UUID != roleUUID || policy.Spec.Resources.FilterList == nil {
+		return false
+	}
+	for _, filterContext := range policy.Spec.Resources.FilterList.ContextList {
+		for _, scope := range filterContext.ScopeFilterExpressionList {
+			if scope.LeftHandSide != schema.ScopeProject || scope.Operator != schema.FilterOperatorIn || scope.RightHandSide == nil {
+				continue
+			}
+			for _, uuid := range scope.RightHandSide.UUIDList {
+				if uuid == projectUUID {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
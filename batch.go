@@ -0,0 +1,195 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+// BatchClient runs operations across many entities with bounded concurrency.
+type BatchClient struct {
+	client *Client
+}
+
+// BatchOptions configures a batch run
+type BatchOptions struct {
+	// Filter selects the vms in FIQL syntax, for example "power_state==on". All vms are selected if empty.
+	Filter string
+
+	// Skip is called for every selected vm. A non-empty reason skips the vm.
+	Skip func(vm *schema.VMIntent) (reason string)
+
+	// Concurrency is the maximum number of operations running at once. Defaults to 1.
+	Concurrency int
+
+	// FailFast stops starting new operations after the first failure. The remaining
+	// vms are reported as skipped.
+	FailFast bool
+}
+
+// VMOperation is run for every vm of a batch. If it returns a task uuid, the batch waits for the task.
+type VMOperation func(ctx context.Context, vm *schema.VMIntent) (taskUUID string, err error)
+
+// BatchItem is the outcome of an operation on a single entity
+type BatchItem struct {
+	UUID     string
+	Name     string
+	TaskUUID string
+
+	// Err is set for failed items
+	Err error
+
+	// Reason is set for skipped items
+	Reason string
+}
+
+// BatchResult lists the entities of a batch by outcome
+type BatchResult struct {
+	Succeeded []*BatchItem
+	Failed    []*BatchItem
+	Skipped   []*BatchItem
+}
+
+// Err returns an error summarizing the failed items, nil if there are none
+func (r *BatchResult) Err() error {
+	if len(r.Failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d operations failed, first error: %s: %v", len(r.Failed), len(r.Succeeded)+len(r.Failed), r.Failed[0].UUID, r.Failed[0].Err)
+}
+
+// ForEachVM runs op on every vm selected by opts and waits for the returned tasks. The returned
+// error is only set if the vms could not be listed, failures of op are reported in the result.
+func (c *BatchClient) ForEachVM(ctx context.Context, opts *BatchOptions, op VMOperation) (*BatchResult, error) {
+	if opts == nil {
+		opts = &BatchOptions{}
+	}
+	vms, err := c.client.VM.ListAll(ctx, opts.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return c.run(ctx, vms, opts, op), nil
+}
+
+// run runs op on every vm honoring the concurrency, skip and fail-fast options
+func (c *BatchClient) run(ctx context.Context, vms []*schema.VMIntent, opts *BatchOptions, op VMOperation) *BatchResult {
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	result := new(BatchResult)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var failed bool
+	sem := make(chan struct{}, concurrency)
+
+	for _, vm := range vms {
+		item := &BatchItem{UUID: vm.Metadata.UUID}
+		if vm.Spec != nil {
+			item.Name = vm.Spec.Name
+		}
+
+		if opts.Skip != nil {
+			if reason := opts.Skip(vm); reason != "" {
+				item.Reason = reason
+				mu.Lock()
+				result.Skipped = append(result.Skipped, item)
+				mu.Unlock()
+				continue
+			}
+		}
+
+		sem <- struct{}{}
+		mu.Lock()
+		stop := failed && opts.FailFast
+		if stop {
+			item.Reason = "batch stopped after a failure"
+			result.Skipped = append(result.Skipped, item)
+		}
+		mu.Unlock()
+		if stop {
+			<-sem
+			continue
+		}
+
+		wg.Add(1)
+		go func(vm *schema.VMIntent, item *BatchItem) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			item.TaskUUID, item.Err = op(ctx, vm)
+			if item.Err == nil {
+				_, item.Err = c.client.Task.Wait(ctx, item.TaskUUID)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if item.Err != nil {
+				failed = true
+				result.Failed = append(result.Failed, item)
+				return
+			}
+			result.Succeeded = append(result.Succeeded, item)
+		}(vm, item)
+	}
+	wg.Wait()
+	return result
+}
+
+// PowerOn returns an operation which powers vms on
+func (c *BatchClient) PowerOn(timeout time.Duration) VMOperation {
+	return func(ctx context.Context, vm *schema.VMIntent) (string, error) {
+		_, err := c.client.VM.PowerOn(ctx, vm, timeout)
+		return "", err
+	}
+}
+
+// Shutdown returns an operation which shuts vms down like VMClient.Shutdown
+func (c *BatchClient) Shutdown(mechanism string, timeout time.Duration) VMOperation {
+	return func(ctx context.Context, vm *schema.VMIntent) (string, error) {
+		_, err := c.client.VM.Shutdown(ctx, vm, mechanism, timeout)
+		return "", err
+	}
+}
+
+// Snapshot returns an operation which creates a v3 vm snapshot of each vm
+func (c *BatchClient) Snapshot(name, snapshotType string, expiration time.Time) VMOperation {
+	return func(ctx context.Context, vm *schema.VMIntent) (string, error) {
+		snapshot, err := c.client.VMSnapshot.Create(ctx, vm.Metadata.UUID, name, snapshotType, expiration)
+		if err != nil || snapshot.Status == nil {
+			return "", err
+		}
+		return snapshot.Status.ExecutionContext.GetTaskUUID(), nil
+	}
+}
+
+// AssignCategory returns an operation which assigns the category value to each vm
+func (c *BatchClient) AssignCategory(key, value string) VMOperation {
+	return func(ctx context.Context, vm *schema.VMIntent) (string, error) {
+		_, taskUUID, err := c.client.VM.UpdateWith(ctx, vm.Metadata.UUID, func(v *schema.VMIntent) error {
+			if v.Metadata.Categories == nil {
+				v.Metadata.Categories = make(map[string]string)
+			}
+			v.Metadata.Categories[key] = value
+			return nil
+		})
+		return taskUUID, err
+	}
+}
+
+// Delete returns an operation which deletes each vm
+func (c *BatchClient) Delete() VMOperation {
+	return func(ctx context.Context, vm *schema.VMIntent) (string, error) {
+		response := new(schema.DeleteResponse)
+		err := c.client.requestHelper(ctx, fmt.Sprintf(vmSinglePath, vm.Metadata.UUID), http.MethodDelete, nil, response)
+		if err != nil || response.Status == nil {
+			return "", err
+		}
+		return response.Status.ExecutionContext.GetTaskUUID(), nil
+	}
+}
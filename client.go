@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/rand"
 	"net"
 	"net/http"
 	"net/http/httputil"
@@ -27,8 +28,18 @@ const (
 	itemsPerPage     int64 = 500
 	mediaTypeJSON          = "application/json"
 	mediaTypeUpload        = "application/octet-stream"
+
+	defaultMaxUpdateRetries = 5
+
+	conflictBackoffBase = 250 * time.Millisecond
+	conflictBackoffMax  = 5 * time.Second
 )
 
+// ErrConflict is returned when the API rejects a request with 409 Conflict, for example
+// because the spec_version sent does not match the current spec_version of the entity, the
+// entity is locked by a running task or the name is already in use.
+var ErrConflict = errors.New("conflict")
+
 // ClientOption ...
 type ClientOption func(*Client)
 
@@ -41,6 +52,8 @@ type Client struct {
 	skipVerify  bool
 	debugWriter io.Writer
 
+	maxUpdateRetries int
+
 	Image            ImageClient
 	Cluster          ClusterClient
 	Project          ProjectClient
@@ -53,8 +66,27 @@ type Client struct {
 	AvailabilityZone AvailabilityZoneClient
 	VMRecoveryPoint  VMRecoveryPointClient
 	VPC              VpcClient
+	VirtualNetwork   VirtualNetworkClient
 	FlotatingIP      FloatingIPClient
 	RoutingPolicy    RoutingPolicyClient
+	VMSnapshot       VMSnapshotClient
+	Batch            BatchClient
+	Stats            StatsClient
+	StorageContainer StorageContainerClient
+	Groups           GroupsClient
+
+	User                UserClient
+	UserGroup           UserGroupClient
+	Role                RoleClient
+	Permission          PermissionClient
+	AccessControlPolicy AccessControlPolicyClient
+	DirectoryService    DirectoryServiceClient
+	IdentityProvider    IdentityProviderClient
+	ProtectionRule      ProtectionRuleClient
+	RecoveryPlan        RecoveryPlanClient
+	RecoveryPlanJob     RecoveryPlanJobClient
+	VpnGateway          VpnGatewayClient
+	VpnConnection       VpnConnectionClient
 }
 
 // Credentials needed username and password
@@ -105,9 +137,20 @@ func WithDebugWriter(debugWriter io.Writer) ClientOption {
 	}
 }
 
+// WithMaxUpdateRetries configures how many times UpdateWith re-applies a mutation
+// after a conflict. Negative values are treated as zero.
+func WithMaxUpdateRetries(retries int) ClientOption {
+	return func(client *Client) {
+		if retries < 0 {
+			retries = 0
+		}
+		client.maxUpdateRetries = retries
+	}
+}
+
 // NewClient creates a new client.
 func NewClient(options ...ClientOption) *Client {
-	client := &Client{}
+	client := &Client{maxUpdateRetries: defaultMaxUpdateRetries}
 
 	for _, option := range options {
 		option(client)
@@ -148,8 +191,26 @@ func NewClient(options ...ClientOption) *Client {
 	client.AvailabilityZone = AvailabilityZoneClient{client: client}
 	client.VMRecoveryPoint = VMRecoveryPointClient{client: client}
 	client.VPC = VpcClient{client: client}
+	client.VirtualNetwork = VirtualNetworkClient{client: client}
 	client.FlotatingIP = FloatingIPClient{client: client}
 	client.RoutingPolicy = RoutingPolicyClient{client: client}
+	client.VMSnapshot = VMSnapshotClient{client: client}
+	client.Batch = BatchClient{client: client}
+	client.Stats = StatsClient{client: client}
+	client.StorageContainer = StorageContainerClient{client: client}
+	client.Groups = GroupsClient{client: client}
+	client.User = UserClient{client: client}
+	client.UserGroup = UserGroupClient{client: client}
+	client.Role = RoleClient{client: client}
+	client.Permission = PermissionClient{client: client}
+	client.AccessControlPolicy = AccessControlPolicyClient{client: client}
+	client.DirectoryService = DirectoryServiceClient{client: client}
+	client.IdentityProvider = IdentityProviderClient{client: client}
+	client.ProtectionRule = ProtectionRuleClient{client: client}
+	client.RecoveryPlan = RecoveryPlanClient{client: client}
+	client.RecoveryPlanJob = RecoveryPlanJobClient{client: client}
+	client.VpnGateway = VpnGatewayClient{client: client}
+	client.VpnConnection = VpnConnectionClient{client: client}
 	return client
 }
 
@@ -220,6 +281,10 @@ func checkResponse(r *http.Response) error {
 		return fmt.Errorf("statusCode: %d, response: %s", r.StatusCode, string(buf))
 	}
 
+	if r.StatusCode == http.StatusConflict {
+		return errors.Wrapf(ErrConflict, "statusCode: %d, response: %s", r.StatusCode, string(buf))
+	}
+
 	data := io.NopCloser(bytes.NewBuffer(buf))
 
 	r.Body = data
@@ -394,3 +459,40 @@ func (c *Client) requestHelper(ctx context.Context, path, method string, request
 
 	return nil
 }
+
+// retryOnConflict runs fn until it succeeds, fails with an error other than
+// ErrConflict or the configured number of retries is exhausted. Retries are delayed by an
+// exponential backoff with jitter, so a task holding the entity has time to finish.
+func (c *Client) retryOnConflict(ctx context.Context, fn func() error) error {
+	var err error
+	for attempt := 0; attempt <= c.maxUpdateRetries; attempt++ {
+		err = fn()
+		if err == nil || !errors.Is(err, ErrConflict) {
+			return err
+		}
+		if attempt == c.maxUpdateRetries {
+			break
+		}
+		timer := time.NewTimer(conflictBackoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return errors.Wrapf(err, "giving up after %d retries", c.maxUpdateRetries)
+}
+
+// conflictBackoff returns the delay before retry attempt+1, doubling per attempt up to
+// conflictBackoffMax with up to 50% random jitter
+func conflictBackoff(attempt int) time.Duration {
+	backoff := conflictBackoffMax
+	if attempt < 5 {
+		backoff = conflictBackoffBase << uint(attempt)
+		if backoff > conflictBackoffMax {
+			backoff = conflictBackoffMax
+		}
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
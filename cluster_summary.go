@@ -0,0 +1,181 @@
+package nutanix
+
+import (
+	"context"
+	"sort"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/pkg/utils"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+// ClusterSummary is the capacity and allocation of a cluster. Allocations are summed from the
+// powered on vms, which are the ones holding host resources.
+type ClusterSummary struct {
+	UUID string
+	Name string
+
+	// AOSVersion is the version of the cluster software
+	AOSVersion string
+
+	// HypervisorVersions are the distinct hypervisor types and versions of the nodes
+	HypervisorVersions []string
+
+	RedundancyFactor int64
+
+	Hosts []*HostSummary
+
+	CPUCores           int64
+	MemoryCapacityMib  int64
+	AllocatedVCPUs     int64
+	AllocatedMemoryMib int64
+
+	// VCPURatio is the number of allocated vcpus per physical core
+	VCPURatio float64
+
+	// MemoryRatio is the allocated memory divided by the memory capacity
+	MemoryRatio float64
+
+	// N1VCPURatio is VCPURatio with the largest host down
+	N1VCPURatio float64
+
+	// N1MemoryHeadroomMib is the unallocated memory with the largest host down. A negative
+	// value means the powered on vms do not fit on the cluster if a host fails.
+	N1MemoryHeadroomMib int64
+}
+
+// HostSummary is the capacity and allocation of a host
+type HostSummary struct {
+	UUID string
+	Name string
+
+	CPUSockets         int64
+	CPUCores           int64
+	MemoryCapacityMib  int64
+	AllocatedVCPUs     int64
+	AllocatedMemoryMib int64
+	NumVMs             int
+}
+
+// Summary returns the capacity and allocation report of a cluster
+func (c *ClusterClient) Summary(ctx context.Context, uuid string) (*ClusterSummary, error) {
+	cluster, err := c.GetByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	hosts, err := c.client.Host.listByCluster(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	vms, err := c.client.VM.ListAll(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return SummarizeCluster(cluster, hosts, vms), nil
+}
+
+// SummarizeCluster builds the capacity and allocation report of a cluster from hosts and vms
+// listed beforehand, so several clusters can be summarized from one list of each. Hosts and
+// vms of other clusters are ignored.
+func SummarizeCluster(cluster *schema.ClusterIntent, hosts []*schema.HostIntent, vms []*schema.VMIntent) *ClusterSummary {
+	uuid := cluster.Metadata.UUID
+	summary := &ClusterSummary{UUID: uuid, RedundancyFactor: defaultRedundancyFactor}
+	if cluster.Spec != nil {
+		summary.Name = cluster.Spec.Name
+	}
+	if cluster.Status != nil && cluster.Status.Resources != nil {
+		resources := cluster.Status.Resources
+		if config := resources.Config; config != nil {
+			if config.Build != nil && config.Build.Version != nil {
+				summary.AOSVersion = *config.Build.Version
+			} else if config.SoftwareMap != nil && config.SoftwareMap.NOS != nil {
+				summary.AOSVersion = utils.StringValue(config.SoftwareMap.NOS.Version)
+			}
+			if config.RedundancyFactor != nil {
+				summary.RedundancyFactor = *config.RedundancyFactor
+			}
+		}
+		if resources.Nodes != nil {
+			seen := make(map[string]bool)
+			for _, server := range resources.Nodes.HypervisorServerList {
+				version := server.Type + " " + server.Version
+				if !seen[version] {
+					seen[version] = true
+					summary.HypervisorVersions = append(summary.HypervisorVersions, version)
+				}
+			}
+			sort.Strings(summary.HypervisorVersions)
+		}
+	}
+
+	byUUID := make(map[string]*HostSummary, len(hosts))
+	for _, host := range hosts {
+		if host.Status == nil || host.Status.ClusterReference == nil || host.Status.ClusterReference.UUID != uuid {
+			continue
+		}
+		hostSummary := &HostSummary{UUID: host.Metadata.UUID, Name: host.Status.Name}
+		if resources := host.Status.Resources; resources != nil {
+			hostSummary.CPUSockets = int64(resources.NumCPUSockets)
+			hostSummary.CPUCores = int64(resources.NumCPUCores)
+			hostSummary.MemoryCapacityMib = int64(resources.MemoryCapacityMib)
+		}
+		byUUID[hostSummary.UUID] = hostSummary
+		summary.Hosts = append(summary.Hosts, hostSummary)
+		summary.CPUCores += hostSummary.CPUCores
+		summary.MemoryCapacityMib += hostSummary.MemoryCapacityMib
+	}
+
+	for _, vm := range vms {
+		if vm.Spec == nil || vm.Spec.Resources == nil || vm.Spec.ClusterReference == nil ||
+			vm.Spec.ClusterReference.UUID != uuid || vm.Spec.Resources.PowerState != vmPowerStateOn {
+			continue
+		}
+		vcpus := VMVCPUs(vm.Spec.Resources)
+		summary.AllocatedVCPUs += vcpus
+		summary.AllocatedMemoryMib += vm.Spec.Resources.MemorySizeMib
+
+		if vm.Status == nil || vm.Status.Resources == nil || vm.Status.Resources.HostReference == nil {
+			continue
+		}
+		if hostSummary, ok := byUUID[vm.Status.Resources.HostReference.UUID]; ok {
+			hostSummary.AllocatedVCPUs += vcpus
+			hostSummary.AllocatedMemoryMib += vm.Spec.Resources.MemorySizeMib
+			hostSummary.NumVMs++
+		}
+	}
+
+	var largestCores, largestMemoryMib int64
+	for _, hostSummary := range summary.Hosts {
+		if hostSummary.CPUCores > largestCores {
+			largestCores = hostSummary.CPUCores
+		}
+		if hostSummary.MemoryCapacityMib > largestMemoryMib {
+			largestMemoryMib = hostSummary.MemoryCapacityMib
+		}
+	}
+	summary.VCPURatio = ratio(summary.AllocatedVCPUs, summary.CPUCores)
+	summary.MemoryRatio = ratio(summary.AllocatedMemoryMib, summary.MemoryCapacityMib)
+	summary.N1VCPURatio = ratio(summary.AllocatedVCPUs, summary.CPUCores-largestCores)
+	summary.N1MemoryHeadroomMib = summary.MemoryCapacityMib - largestMemoryMib - summary.AllocatedMemoryMib
+	return summary
+}
+
+// VMVCPUs returns the number of vcpus of a vm, sockets times vcpus per socket
+func VMVCPUs(resources *schema.VMResources) int64 {
+	sockets := resources.NumSockets
+	if sockets < 1 {
+		sockets = 1
+	}
+	perSocket := resources.NumVcpusPerSocket
+	if perSocket < 1 {
+		perSocket = 1
+	}
+	return sockets * perSocket
+}
+
+// ratio returns a / b, 0 if b is not positive
+func ratio(a, b int64) float64 {
+	if b <= 0 {
+		return 0
+	}
+	return float64(a) / float64(b)
+}
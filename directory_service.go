@@ -0,0 +1,280 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/pkg/utils"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+const (
+	directoryServiceBasePath   = "/directory_services"
+	directoryServiceListPath   = directoryServiceBasePath + "/list"
+	directoryServiceSinglePath = directoryServiceBasePath + "/%s"
+	directoryServiceSearchPath = directoryServiceSinglePath + "/search"
+
+	attributeUserPrincipalName = "userPrincipalName"
+	attributeCommonName        = "cn"
+)
+
+// DirectoryServiceClient is a client for the directory service API.
+type DirectoryServiceClient struct {
+	client *Client
+}
+
+// Get retrieves a directory service by its UUID if the input can be parsed as an uuid, otherwise it
+// retrieves a directory service by its name
+func (c *DirectoryServiceClient) Get(ctx context.Context, idOrName string) (*schema.DirectoryServiceIntent, error) {
+	if utils.IsValidUUID(idOrName) {
+		return c.GetByUUID(ctx, idOrName)
+	}
+	return c.GetByName(ctx, idOrName)
+}
+
+// GetByUUID retrieves a directory service by its UUID
+func (c *DirectoryServiceClient) GetByUUID(ctx context.Context, uuid string) (*schema.DirectoryServiceIntent, error) {
+	response := new(schema.DirectoryServiceIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(directoryServiceSinglePath, uuid), http.MethodGet, nil, response)
+	return response, err
+}
+
+// GetByName retrieves a directory service by its name
+func (c *DirectoryServiceClient) GetByName(ctx context.Context, name string) (*schema.DirectoryServiceIntent, error) {
+	list, err := c.List(ctx, &schema.DSMetadata{Filter: fmt.Sprintf("name==%s", name)})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Entities) == 0 {
+		return nil, fmt.Errorf("directory service not found: %s", name)
+	}
+	return list.Entities[0], err
+}
+
+// List returns a list of directory services
+func (c *DirectoryServiceClient) List(ctx context.Context, opts *schema.DSMetadata) (*schema.DirectoryServiceListIntent, error) {
+	response := new(schema.DirectoryServiceListIntent)
+	err := c.client.requestHelper(ctx, directoryServiceListPath, http.MethodPost, opts, response)
+	return response, err
+
+}
+
+// All returns all directory services
+func (c *DirectoryServiceClient) All(ctx context.Context) (*schema.DirectoryServiceListIntent, error) {
+	return c.List(ctx, &schema.DSMetadata{Length: utils.Int64Ptr(itemsPerPage), Offset: utils.Int64Ptr(0)})
+}
+
+// Create creates a directory service
+func (c *DirectoryServiceClient) Create(ctx context.Context, createRequest *schema.DirectoryServiceIntent) (*schema.DirectoryServiceIntent, error) {
+	response := new(schema.DirectoryServiceIntent)
+	err := c.client.requestHelper(ctx, directoryServiceBasePath, http.MethodPost, createRequest, response)
+	return response, err
+}
+
+// Update a directory service
+func (c *DirectoryServiceClient) Update(ctx context.Context, directoryService *schema.DirectoryServiceIntent) (*schema.DirectoryServiceIntent, error) {
+	response := new(schema.DirectoryServiceIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(directoryServiceSinglePath, directoryService.Metadata.UUID), http.MethodPut, directoryService.ToUpdateRequest(), response)
+	return response, err
+}
+
+// UpdateWith fetches the latest directory service, applies mutate to it and updates it. On a
+// spec_version conflict the directory service is fetched again and mutate is re-applied.
+// It returns the updated directory service and the uuid of the update task.
+func (c *DirectoryServiceClient) UpdateWith(ctx context.Context, uuid string, mutate func(*schema.DirectoryServiceIntent) error) (*schema.DirectoryServiceIntent, string, error) {
+	var response *schema.DirectoryServiceIntent
+	err := c.client.retryOnConflict(ctx, func() error {
+		directoryService, err := c.GetByUUID(ctx, uuid)
+		if err != nil {
+			return err
+		}
+		if err = mutate(directoryService); err != nil {
+			return err
+		}
+		response, err = c.Update(ctx, directoryService)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	var taskUUID string
+	if response.Status != nil {
+		taskUUID = response.Status.ExecutionContext.GetTaskUUID()
+	}
+	return response, taskUUID, nil
+}
+
+// Delete deletes a directory service
+func (c *DirectoryServiceClient) Delete(ctx context.Context, uuid string) error {
+	return c.client.requestHelper(ctx, fmt.Sprintf(directoryServiceSinglePath, uuid), http.MethodDelete, nil, nil)
+}
+
+// Search searches users and groups of the directory service
+func (c *DirectoryServiceClient) Search(ctx context.Context, uuid string, searchRequest *schema.DirectoryServiceSearchRequest) (*schema.DirectoryServiceSearchResponse, error) {
+	response := new(schema.DirectoryServiceSearchResponse)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(directoryServiceSearchPath, uuid), http.MethodPost, searchRequest, response)
+	return response, err
+}
+
+// SearchUsers returns the users whose user principal name or common name starts with query
+func (c *DirectoryServiceClient) SearchUsers(ctx context.Context, uuid, query string) ([]*schema.DirectoryServiceSearchResult, error) {
+	return c.searchType(ctx, uuid, schema.DirectoryEntryTypePerson, &schema.DirectoryServiceSearchRequest{
+		Query:                 query,
+		SearchedAttributeList: []string{attributeUserPrincipalName, attributeCommonName},
+		ReturnedAttributeList: []string{attributeUserPrincipalName, attributeCommonName},
+		IsWildcardSearch:      true,
+	})
+}
+
+// SearchGroups returns the groups whose common name starts with query
+func (c *DirectoryServiceClient) SearchGroups(ctx context.Context, uuid, query string) ([]*schema.DirectoryServiceSearchResult, error) {
+	return c.searchType(ctx, uuid, schema.DirectoryEntryTypeGroup, &schema.DirectoryServiceSearchRequest{
+		Query:                 query,
+		SearchedAttributeList: []string{attributeCommonName},
+		ReturnedAttributeList: []string{attributeCommonName},
+		IsWildcardSearch:      true,
+	})
+}
+
+// ResolveUser returns a user reference for the user principal name, which can be added to
+// ProjectResources.UserReferenceList. The user must exist in the directory service and is
+// created if it is not known yet.
+func (c *DirectoryServiceClient) ResolveUser(ctx context.Context, directoryService *schema.DirectoryServiceIntent, upn string) (*schema.Reference, error) {
+	list, err := c.client.User.List(ctx, &schema.DSMetadata{Filter: fmt.Sprintf("username==%s", upn)})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Entities) > 0 {
+		return &schema.Reference{Kind: "user", UUID: list.Entities[0].Metadata.UUID, Name: upn}, nil
+	}
+
+	results, err := c.searchType(ctx, directoryService.Metadata.UUID, schema.DirectoryEntryTypePerson, &schema.DirectoryServiceSearchRequest{
+		Query:                 upn,
+		SearchedAttributeList: []string{attributeUserPrincipalName},
+		ReturnedAttributeList: []string{attributeUserPrincipalName},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("user not found in directory service %s: %s", directoryService.Metadata.UUID, upn)
+	}
+
+	user, err := c.client.User.Create(ctx, &schema.UserIntent{
+		Metadata: &schema.Metadata{Kind: "user"},
+		Spec: &schema.User{
+			Resources: &schema.UserResources{
+				DirectoryServiceUser: &schema.DirectoryServiceUser{
+					UserPrincipalName:         upn,
+					DirectoryServiceReference: &schema.Reference{Kind: "directory_service", UUID: directoryService.Metadata.UUID},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if user.Status != nil {
+		if _, err = c.client.Task.Wait(ctx, user.Status.ExecutionContext.GetTaskUUID()); err != nil {
+			return nil, err
+		}
+	}
+	return &schema.Reference{Kind: "user", UUID: user.Metadata.UUID, Name: upn}, nil
+}
+
+// ResolveGroup returns a user group reference for the group with the common name, which can be
+// added to ProjectResources.ExternalUserGroupReferenceList. The group must exist in the directory
+// service and is created if it is not known yet. An error is returned if several groups of the
+// directory service have the common name.
+func (c *DirectoryServiceClient) ResolveGroup(ctx context.Context, directoryService *schema.DirectoryServiceIntent, name string) (*schema.Reference, error) {
+	results, err := c.searchType(ctx, directoryService.Metadata.UUID, schema.DirectoryEntryTypeGroup, &schema.DirectoryServiceSearchRequest{
+		Query:                 name,
+		SearchedAttributeList: []string{attributeCommonName},
+		ReturnedAttributeList: []string{attributeCommonName},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var matches []string
+	for _, result := range results {
+		if strings.EqualFold(result.Attribute(attributeCommonName), name) {
+			matches = append(matches, result.Name)
+		}
+	}
+	switch {
+	case len(matches) == 0:
+		return nil, fmt.Errorf("group not found in directory service %s: %s", directoryService.Metadata.UUID, name)
+	case len(matches) > 1:
+		sort.Strings(matches)
+		return nil, fmt.Errorf("groups %s of directory service %s match the common name %s", strings.Join(matches, "; "), directoryService.Metadata.UUID, name)
+	}
+	distinguishedName := matches[0]
+
+	group, err := c.userGroupByDistinguishedName(ctx, distinguishedName)
+	if err != nil {
+		return nil, err
+	}
+	if group != nil {
+		return &schema.Reference{Kind: "user_group", UUID: group.Metadata.UUID, Name: distinguishedName}, nil
+	}
+
+	group, err = c.client.UserGroup.Create(ctx, &schema.UserGroupIntent{
+		Metadata: &schema.Metadata{Kind: "user_group"},
+		Spec: &schema.UserGroup{
+			Resources: &schema.UserGroupResources{
+				DirectoryServiceUserGroup: &schema.DirectoryServiceUserGroup{
+					DistinguishedName: distinguishedName,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if group.Status != nil {
+		if _, err = c.client.Task.Wait(ctx, group.Status.ExecutionContext.GetTaskUUID()); err != nil {
+			return nil, err
+		}
+	}
+	return &schema.Reference{Kind: "user_group", UUID: group.Metadata.UUID, Name: distinguishedName}, nil
+}
+
+// userGroupByDistinguishedName pages through all user groups and returns the one with the
+// distinguished name, nil if there is none
+func (c *DirectoryServiceClient) userGroupByDistinguishedName(ctx context.Context, distinguishedName string) (*schema.UserGroupIntent, error) {
+	var offset int64
+	for {
+		list, err := c.client.UserGroup.List(ctx, &schema.DSMetadata{Length: utils.Int64Ptr(itemsPerPage), Offset: utils.Int64Ptr(offset)})
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range list.Entities {
+			if group.Status != nil && group.Status.Resources != nil && group.Status.Resources.DirectoryServiceUserGroup != nil &&
+				strings.EqualFold(group.Status.Resources.DirectoryServiceUserGroup.DistinguishedName, distinguishedName) {
+				return group, nil
+			}
+		}
+		offset += int64(len(list.Entities))
+		if len(list.Entities) == 0 || list.Metadata == nil || offset >= list.Metadata.TotalMatches {
+			return nil, nil
+		}
+	}
+}
+
+// searchType searches the directory service and returns the results of the given type
+func (c *DirectoryServiceClient) searchType(ctx context.Context, uuid, entryType string, searchRequest *schema.DirectoryServiceSearchRequest) ([]*schema.DirectoryServiceSearchResult, error) {
+	response, err := c.Search(ctx, uuid, searchRequest)
+	if err != nil {
+		return nil, err
+	}
+	var results []*schema.DirectoryServiceSearchResult
+	for _, result := range response.SearchResultList {
+		if result.Type == entryType {
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
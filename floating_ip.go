@@ -70,12 +70,37 @@ func (c *FloatingIPClient) Create(ctx context.Context, createRequest *schema.Flo
 
 // Update a FlotatingIp
 func (c *FloatingIPClient) Update(ctx context.Context, fip *schema.FloatingIPIntent) (*schema.FloatingIPIntent, error) {
-	fip.Status = nil
 	response := new(schema.FloatingIPIntent)
-	err := c.client.requestHelper(ctx, fmt.Sprintf(floatingIPSinglePath, fip.Metadata.UUID), http.MethodPut, fip, response)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(floatingIPSinglePath, fip.Metadata.UUID), http.MethodPut, fip.ToUpdateRequest(), response)
 	return response, err
 }
 
+// UpdateWith fetches the latest floating ip, applies mutate to it and updates it. On a
+// spec_version conflict the floating ip is fetched again and mutate is re-applied.
+// It returns the updated floating ip and the uuid of the update task.
+func (c *FloatingIPClient) UpdateWith(ctx context.Context, uuid string, mutate func(*schema.FloatingIPIntent) error) (*schema.FloatingIPIntent, string, error) {
+	var response *schema.FloatingIPIntent
+	err := c.client.retryOnConflict(ctx, func() error {
+		fip, err := c.GetByUUID(ctx, uuid)
+		if err != nil {
+			return err
+		}
+		if err = mutate(fip); err != nil {
+			return err
+		}
+		response, err = c.Update(ctx, fip)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	var taskUUID string
+	if response.Status != nil {
+		taskUUID = response.Status.ExecutionContext.GetTaskUUID()
+	}
+	return response, taskUUID, nil
+}
+
 // Delete deletes a FlotatingIp
 func (c *FloatingIPClient) Delete(ctx context.Context, uuid string) error {
 	return c.client.requestHelper(ctx, fmt.Sprintf(floatingIPSinglePath, uuid), http.MethodDelete, nil, nil)
@@ -0,0 +1,199 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/pkg/utils"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+const (
+	groupsBasePath = "/groups"
+	groupsPageSize = 500
+
+	// groupsTag is the struct tag DecodeGroups maps attributes with
+	groupsTag = "groups"
+
+	// GroupsEntityID is the pseudo attribute holding the entity uuid when decoding
+	GroupsEntityID = "entity_id"
+)
+
+// GroupsClient is a client for the groups (analytics) API.
+type GroupsClient struct {
+	client *Client
+}
+
+// GroupsQuery builds a groups request
+type GroupsQuery struct {
+	request schema.GroupsRequest
+}
+
+// NewGroupsQuery creates a query for entities of entityType, for example mh_vm
+func NewGroupsQuery(entityType string) *GroupsQuery {
+	return &GroupsQuery{request: schema.GroupsRequest{EntityType: entityType}}
+}
+
+// Attributes adds attributes returned for every entity
+func (q *GroupsQuery) Attributes(attributes ...string) *GroupsQuery {
+	for _, attribute := range attributes {
+		q.request.GroupMemberAttributes = append(q.request.GroupMemberAttributes, &schema.GroupsAttribute{Attribute: attribute})
+	}
+	return q
+}
+
+// Filter sets the filter criteria in FIQL syntax
+func (q *GroupsQuery) Filter(filter string) *GroupsQuery {
+	q.request.FilterCriteria = filter
+	return q
+}
+
+// EntityIDs limits the query to the given entity uuids
+func (q *GroupsQuery) EntityIDs(uuids ...string) *GroupsQuery {
+	q.request.EntityIDs = append(q.request.EntityIDs, uuids...)
+	return q
+}
+
+// Sort sorts the entities by attribute
+func (q *GroupsQuery) Sort(attribute string, descending bool) *GroupsQuery {
+	q.request.GroupMemberSortAttribute = attribute
+	q.request.GroupMemberSortOrder = schema.GroupsSortOrderAscending
+	if descending {
+		q.request.GroupMemberSortOrder = schema.GroupsSortOrderDescending
+	}
+	return q
+}
+
+// Page selects count entities starting at offset
+func (q *GroupsQuery) Page(count, offset int64) *GroupsQuery {
+	q.request.GroupMemberCount = utils.Int64Ptr(count)
+	q.request.GroupMemberOffset = utils.Int64Ptr(offset)
+	return q
+}
+
+// Request returns a copy of the request built so far
+func (q *GroupsQuery) Request() *schema.GroupsRequest {
+	request := q.request
+	return &request
+}
+
+// Query runs a single groups request
+func (c *GroupsClient) Query(ctx context.Context, query *GroupsQuery) (*schema.GroupsResponse, error) {
+	response := new(schema.GroupsResponse)
+	err := c.client.requestHelper(ctx, groupsBasePath, http.MethodPost, query.Request(), response)
+	return response, err
+}
+
+// QueryAll pages through all entities matching the query, ignoring its page settings
+func (c *GroupsClient) QueryAll(ctx context.Context, query *GroupsQuery) ([]*schema.GroupsEntityResult, error) {
+	request := query.Request()
+	page := &GroupsQuery{request: *request}
+
+	var entities []*schema.GroupsEntityResult
+	for offset := int64(0); ; offset += groupsPageSize {
+		page.Page(groupsPageSize, offset)
+		response, err := c.Query(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		pageEntities := response.Entities()
+		entities = append(entities, pageEntities...)
+		if len(pageEntities) < groupsPageSize || int64(len(entities)) >= response.FilteredEntityCount {
+			return entities, nil
+		}
+	}
+}
+
+// DecodeGroups decodes entities into out, which must be a pointer to a slice of structs or of
+// pointers to structs. Struct fields are mapped with the `groups:"attribute"` tag, GroupsEntityID
+// maps the entity uuid. Supported field types are strings, bools, integers, floats and string
+// slices, which receive all values of multi-valued attributes.
+func DecodeGroups(entities []*schema.GroupsEntityResult, out interface{}) error {
+	slice := reflect.ValueOf(out)
+	if slice.Kind() != reflect.Ptr || slice.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("decode groups: out must be a pointer to a slice, got %T", out)
+	}
+	slice = slice.Elem()
+
+	elemType := slice.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	structType := elemType
+	if isPtr {
+		structType = elemType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("decode groups: slice elements must be structs, got %s", elemType)
+	}
+
+	for _, entity := range entities {
+		elem := reflect.New(structType).Elem()
+		for i := 0; i < structType.NumField(); i++ {
+			field := structType.Field(i)
+			attribute := strings.Split(field.Tag.Get(groupsTag), ",")[0]
+			if attribute == "" || field.PkgPath != "" {
+				continue
+			}
+
+			values := entity.Values(attribute)
+			if attribute == GroupsEntityID {
+				values = []string{entity.EntityID}
+			}
+			if err := setGroupsField(elem.Field(i), values); err != nil {
+				return fmt.Errorf("decode groups: entity %s attribute %s: %v", entity.EntityID, attribute, err)
+			}
+		}
+
+		if isPtr {
+			elem = elem.Addr()
+		}
+		slice.Set(reflect.Append(slice, elem))
+	}
+	return nil
+}
+
+func setGroupsField(field reflect.Value, values []string) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(values[0])
+	case reflect.Bool:
+		v, err := strconv.ParseBool(values[0])
+		if err != nil {
+			return err
+		}
+		field.SetBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(values[0], 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(values[0], 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(values[0], field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(v)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported field type %s", field.Type())
+		}
+		field.Set(reflect.ValueOf(append([]string(nil), values...)).Convert(field.Type()))
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package nutanix
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+func groupsEntity(uuid string, data map[string][]string) *schema.GroupsEntityResult {
+	entity := &schema.GroupsEntityResult{EntityID: uuid}
+	for name, values := range data {
+		entity.Data = append(entity.Data, &schema.GroupsFieldData{
+			Name:   name,
+			Values: []*schema.GroupsFieldValue{{Values: values}},
+		})
+	}
+	return entity
+}
+
+type groupsVM struct {
+	UUID     string   `groups:"entity_id"`
+	Name     string   `groups:"vm_name"`
+	VCPUs    int      `groups:"num_vcpus"`
+	Memory   uint64   `groups:"memory_size_bytes"`
+	Usage    float64  `groups:"hypervisor_cpu_usage_ppm"`
+	Running  bool     `groups:"is_running"`
+	IPs      []string `groups:"ip_addresses"`
+	Untagged string
+	hidden   string `groups:"vm_name"`
+}
+
+func TestDecodeGroups(t *testing.T) {
+	entity := groupsEntity("vm-1", map[string][]string{
+		"vm_name":                  {"web"},
+		"num_vcpus":                {"4"},
+		"memory_size_bytes":        {"4294967296"},
+		"hypervisor_cpu_usage_ppm": {"12.5"},
+		"is_running":               {"true"},
+		"ip_addresses":             {"10.0.0.1", "10.0.0.2"},
+	})
+	want := groupsVM{
+		UUID:    "vm-1",
+		Name:    "web",
+		VCPUs:   4,
+		Memory:  4294967296,
+		Usage:   12.5,
+		Running: true,
+		IPs:     []string{"10.0.0.1", "10.0.0.2"},
+	}
+
+	tests := []struct {
+		name     string
+		entities []*schema.GroupsEntityResult
+		decode   func([]*schema.GroupsEntityResult) (interface{}, error)
+		want     interface{}
+		err      string
+	}{
+		{
+			name:     "struct elements",
+			entities: []*schema.GroupsEntityResult{entity},
+			decode: func(entities []*schema.GroupsEntityResult) (interface{}, error) {
+				var out []groupsVM
+				err := DecodeGroups(entities, &out)
+				return out, err
+			},
+			want: []groupsVM{want},
+		},
+		{
+			name:     "pointer elements",
+			entities: []*schema.GroupsEntityResult{entity},
+			decode: func(entities []*schema.GroupsEntityResult) (interface{}, error) {
+				var out []*groupsVM
+				err := DecodeGroups(entities, &out)
+				return out, err
+			},
+			want: []*groupsVM{&want},
+		},
+		{
+			name:     "missing attributes keep zero values",
+			entities: []*schema.GroupsEntityResult{groupsEntity("vm-2", nil)},
+			decode: func(entities []*schema.GroupsEntityResult) (interface{}, error) {
+				var out []groupsVM
+				err := DecodeGroups(entities, &out)
+				return out, err
+			},
+			want: []groupsVM{{UUID: "vm-2"}},
+		},
+		{
+			name:     "invalid int",
+			entities: []*schema.GroupsEntityResult{groupsEntity("vm-3", map[string][]string{"num_vcpus": {"four"}})},
+			decode: func(entities []*schema.GroupsEntityResult) (interface{}, error) {
+				var out []groupsVM
+				err := DecodeGroups(entities, &out)
+				return out, err
+			},
+			err: "attribute num_vcpus",
+		},
+		{
+			name:     "unsupported field type",
+			entities: []*schema.GroupsEntityResult{groupsEntity("vm-4", map[string][]string{"num_vcpus": {"4"}})},
+			decode: func(entities []*schema.GroupsEntityResult) (interface{}, error) {
+				var out []struct {
+					VCPUs []int `groups:"num_vcpus"`
+				}
+				err := DecodeGroups(entities, &out)
+				return out, err
+			},
+			err: "unsupported field type []int",
+		},
+		{
+			name: "not a pointer to a slice",
+			decode: func(entities []*schema.GroupsEntityResult) (interface{}, error) {
+				var out []groupsVM
+				return nil, DecodeGroups(entities, out)
+			},
+			err: "must be a pointer to a slice",
+		},
+		{
+			name: "not a slice of structs",
+			decode: func(entities []*schema.GroupsEntityResult) (interface{}, error) {
+				var out []string
+				return nil, DecodeGroups(entities, &out)
+			},
+			err: "slice elements must be structs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.decode(tt.entities)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("err = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -59,3 +59,31 @@ func (c *HostClient) List(ctx context.Context, opts *schema.DSMetadata) (*schema
 func (c *HostClient) All(ctx context.Context) (*schema.HostListIntent, error) {
 	return c.List(ctx, &schema.DSMetadata{Length: utils.Int64Ptr(itemsPerPage), Offset: utils.Int64Ptr(0)})
 }
+
+// listByCluster returns all hosts of the cluster
+func (c *HostClient) listByCluster(ctx context.Context, clusterUUID string) ([]*schema.HostIntent, error) {
+	list, err := c.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var hosts []*schema.HostIntent
+	for _, host := range list.Entities {
+		if host.Status != nil && host.Status.ClusterReference != nil && host.Status.ClusterReference.UUID == clusterUUID {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts, nil
+}
+
+// Evacuate live migrates every vm running on the host to other hosts of the cluster, running at
+// most concurrency migrations at once. The cluster chooses the target host of each vm.
+func (c *HostClient) Evacuate(ctx context.Context, host *schema.HostIntent, concurrency int) (*BatchResult, error) {
+	vms, err := c.client.VM.listByHost(ctx, host.Metadata.UUID)
+	if err != nil {
+		return nil, err
+	}
+	return c.client.Batch.run(ctx, vms, &BatchOptions{Concurrency: concurrency}, func(ctx context.Context, vm *schema.VMIntent) (string, error) {
+		_, err := c.client.VM.Migrate(ctx, vm, nil)
+		return "", err
+	}), nil
+}
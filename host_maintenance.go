@@ -0,0 +1,356 @@
+package nutanix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+	v2 "github.com/tecbiz-ch/nutanix-go-sdk/schema/v2"
+)
+
+const (
+	hostEnterMaintenanceModePath = hostSinglePath + "/enter_maintenance_mode"
+	hostExitMaintenanceModePath  = hostSinglePath + "/exit_maintenance_mode"
+
+	hostStateNormal                       = "NORMAL"
+	hypervisorStateNormal                 = "kAcropolisNormal"
+	hypervisorStateEnteredMaintenanceMode = "kEnteredMaintenanceMode"
+
+	defaultRedundancyFactor = 2
+)
+
+// Phases reported through MaintenanceOptions.Progress
+const (
+	MaintenancePhasePrecheck = "precheck"
+	MaintenancePhaseDrain    = "drain"
+	MaintenancePhaseEnter    = "enter"
+	MaintenancePhaseExit     = "exit"
+	MaintenancePhaseDone     = "done"
+)
+
+// NonMigratableVMPolicy decides what happens to vms which cannot be live migrated off a host
+type NonMigratableVMPolicy int
+
+const (
+	// NonMigratableVMFail aborts entering maintenance mode
+	NonMigratableVMFail NonMigratableVMPolicy = iota
+
+	// NonMigratableVMShutdown shuts the vms down
+	NonMigratableVMShutdown
+)
+
+// MaintenanceOptions configures entering and exiting maintenance mode
+type MaintenanceOptions struct {
+	// Concurrency is the maximum number of vms migrated at once. Defaults to 1.
+	Concurrency int
+
+	// NonMigratableVMs decides what happens to vms whose live migration fails
+	NonMigratableVMs NonMigratableVMPolicy
+
+	// ShutdownMechanism is the schema.PowerStateMechanism* used to shut down non-migratable
+	// vms. Defaults to ACPI.
+	ShutdownMechanism string
+
+	// ShutdownTimeout is passed to VMClient.Shutdown
+	ShutdownTimeout time.Duration
+
+	// SkipPrecheck skips MaintenancePrecheck
+	SkipPrecheck bool
+
+	// Progress is called on every phase change and for every drained vm
+	Progress func(*MaintenanceProgress)
+}
+
+// MaintenanceProgress is reported while a host enters or exits maintenance mode
+type MaintenanceProgress struct {
+	Host    *schema.HostIntent
+	Phase   string
+	Message string
+
+	// Item is set for drained vms
+	Item *BatchItem
+}
+
+// DrainResult lists the vms of a host by how they were moved off it
+type DrainResult struct {
+	Migrated []*BatchItem
+	ShutDown []*BatchItem
+	Failed   []*BatchItem
+}
+
+// MaintenancePrecheck verifies the cluster can tolerate the host being down: no more hosts may
+// be unavailable than the redundancy factor allows, and the remaining available hosts must have
+// enough unallocated memory for the powered on vms of the host.
+func (c *HostClient) MaintenancePrecheck(ctx context.Context, host *schema.HostIntent) error {
+	clusterUUID, err := hostClusterUUID(host)
+	if err != nil {
+		return err
+	}
+
+	cluster, err := c.client.Cluster.GetByUUID(ctx, clusterUUID)
+	if err != nil {
+		return err
+	}
+	redundancyFactor := int64(defaultRedundancyFactor)
+	if cluster.Status != nil && cluster.Status.Resources != nil && cluster.Status.Resources.Config != nil &&
+		cluster.Status.Resources.Config.RedundancyFactor != nil {
+		redundancyFactor = *cluster.Status.Resources.Config.RedundancyFactor
+	}
+
+	hosts, err := c.listByCluster(ctx, clusterUUID)
+	if err != nil {
+		return err
+	}
+	vms, err := c.client.VM.ListAll(ctx, "")
+	if err != nil {
+		return err
+	}
+	allocatedMib := make(map[string]int64)
+	for _, vm := range vms {
+		if vm.Spec == nil || vm.Spec.Resources == nil || vm.Spec.Resources.PowerState != vmPowerStateOn ||
+			vm.Status == nil || vm.Status.Resources == nil || vm.Status.Resources.HostReference == nil {
+			continue
+		}
+		allocatedMib[vm.Status.Resources.HostReference.UUID] += vm.Spec.Resources.MemorySizeMib
+	}
+
+	var unavailable int64
+	var freeMib int64
+	for _, other := range hosts {
+		if other.Metadata.UUID == host.Metadata.UUID {
+			continue
+		}
+		state, err := c.getV2(ctx, clusterUUID, other.Metadata.UUID)
+		if err != nil {
+			return err
+		}
+		if !hostAvailable(state) {
+			unavailable++
+			continue
+		}
+		if other.Status != nil && other.Status.Resources != nil {
+			freeMib += int64(other.Status.Resources.MemoryCapacityMib) - allocatedMib[other.Metadata.UUID]
+		}
+	}
+
+	if unavailable+1 > redundancyFactor-1 {
+		return fmt.Errorf("cluster %s with redundancy factor %d cannot tolerate host %s being down, %d other hosts are unavailable",
+			clusterUUID, redundancyFactor, host.Metadata.UUID, unavailable)
+	}
+	if requiredMib := allocatedMib[host.Metadata.UUID]; requiredMib > freeMib {
+		return fmt.Errorf("not enough memory to drain host %s: %d MiB required, %d MiB available on the other hosts",
+			host.Metadata.UUID, requiredMib, freeMib)
+	}
+	return nil
+}
+
+// EnterMaintenanceMode drains the host and puts its hypervisor into maintenance mode. Powered on
+// vms are live migrated to other hosts, vms which cannot be migrated are handled according to
+// opts.NonMigratableVMs. It waits until the hypervisor reports maintenance mode while the CVM
+// stays up. The drain result is returned even if a later step fails.
+func (c *HostClient) EnterMaintenanceMode(ctx context.Context, host *schema.HostIntent, opts *MaintenanceOptions) (*DrainResult, error) {
+	if opts == nil {
+		opts = &MaintenanceOptions{}
+	}
+	clusterUUID, err := hostClusterUUID(host)
+	if err != nil {
+		return nil, err
+	}
+	report := progressReporter(host, opts.Progress)
+
+	if !opts.SkipPrecheck {
+		report(MaintenancePhasePrecheck, "verifying the cluster can tolerate the host being down", nil)
+		if err := c.MaintenancePrecheck(ctx, host); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := c.drain(ctx, host, opts, report)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Failed) > 0 {
+		return result, fmt.Errorf("failed to drain %d vms from host %s, first error: %s: %v",
+			len(result.Failed), host.Metadata.UUID, result.Failed[0].UUID, result.Failed[0].Err)
+	}
+
+	report(MaintenancePhaseEnter, "entering maintenance mode", nil)
+	err = c.setMaintenanceMode(ctx, clusterUUID, fmt.Sprintf(hostEnterMaintenanceModePath, host.Metadata.UUID), &v2.HostMaintenanceMode{EvacuateVMs: true})
+	if err != nil {
+		return result, err
+	}
+	err = c.waitForState(ctx, clusterUUID, host.Metadata.UUID, func(state *v2.Host) bool {
+		return state.HypervisorState == hypervisorStateEnteredMaintenanceMode && state.State == hostStateNormal
+	})
+	if err != nil {
+		return result, err
+	}
+
+	report(MaintenancePhaseDone, "host is in maintenance mode", nil)
+	return result, nil
+}
+
+// ExitMaintenanceMode takes the host out of maintenance mode and waits until its hypervisor and
+// CVM are back to normal. Vms shut down while entering maintenance mode are not powered on.
+func (c *HostClient) ExitMaintenanceMode(ctx context.Context, host *schema.HostIntent, opts *MaintenanceOptions) error {
+	if opts == nil {
+		opts = &MaintenanceOptions{}
+	}
+	clusterUUID, err := hostClusterUUID(host)
+	if err != nil {
+		return err
+	}
+	report := progressReporter(host, opts.Progress)
+
+	report(MaintenancePhaseExit, "exiting maintenance mode", nil)
+	err = c.setMaintenanceMode(ctx, clusterUUID, fmt.Sprintf(hostExitMaintenanceModePath, host.Metadata.UUID), nil)
+	if err != nil {
+		return err
+	}
+	err = c.waitForState(ctx, clusterUUID, host.Metadata.UUID, hostAvailable)
+	if err != nil {
+		return err
+	}
+
+	report(MaintenancePhaseDone, "host is out of maintenance mode", nil)
+	return nil
+}
+
+// drain moves the powered on vms off the host
+func (c *HostClient) drain(ctx context.Context, host *schema.HostIntent, opts *MaintenanceOptions, report func(string, string, *BatchItem)) (*DrainResult, error) {
+	vms, err := c.client.VM.listByHost(ctx, host.Metadata.UUID)
+	if err != nil {
+		return nil, err
+	}
+	report(MaintenancePhaseDrain, fmt.Sprintf("draining %d vms", len(vms)), nil)
+
+	mechanism := opts.ShutdownMechanism
+	if mechanism == "" {
+		mechanism = schema.PowerStateMechanismACPI
+	}
+
+	var mu sync.Mutex
+	shutDown := make(map[string]bool)
+	batch := c.client.Batch.run(ctx, vms, &BatchOptions{
+		Concurrency: opts.Concurrency,
+		Skip: func(vm *schema.VMIntent) string {
+			if vm.Spec == nil || vm.Spec.Resources == nil || vm.Spec.Resources.PowerState != vmPowerStateOn {
+				return "vm is powered off"
+			}
+			return ""
+		},
+	}, func(ctx context.Context, vm *schema.VMIntent) (string, error) {
+		_, err := c.client.VM.Migrate(ctx, vm, nil)
+		if err == nil || opts.NonMigratableVMs != NonMigratableVMShutdown || ctx.Err() != nil {
+			return "", err
+		}
+		if _, err := c.client.VM.Shutdown(ctx, vm, mechanism, opts.ShutdownTimeout); err != nil {
+			return "", err
+		}
+		mu.Lock()
+		shutDown[vm.Metadata.UUID] = true
+		mu.Unlock()
+		return "", nil
+	})
+
+	result := &DrainResult{Failed: batch.Failed}
+	for _, item := range batch.Succeeded {
+		if shutDown[item.UUID] {
+			result.ShutDown = append(result.ShutDown, item)
+			report(MaintenancePhaseDrain, fmt.Sprintf("shut down vm %s", item.Name), item)
+			continue
+		}
+		result.Migrated = append(result.Migrated, item)
+		report(MaintenancePhaseDrain, fmt.Sprintf("migrated vm %s", item.Name), item)
+	}
+	for _, item := range batch.Failed {
+		report(MaintenancePhaseDrain, fmt.Sprintf("failed to drain vm %s: %v", item.Name, item.Err), item)
+	}
+	return result, nil
+}
+
+// setMaintenanceMode posts body to the v2 maintenance mode path and waits for the task
+func (c *HostClient) setMaintenanceMode(ctx context.Context, clusterUUID, path string, body interface{}) error {
+	reqBodyData, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := c.client.NewV2PERequest(ctx, http.MethodPost, clusterUUID, path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return err
+	}
+
+	task := new(v2.Task)
+	if err = c.client.Do(req, &task); err != nil {
+		return err
+	}
+	if task.TaskUUID == "" {
+		return fmt.Errorf("maintenance mode request %s returned no task", path)
+	}
+	_, err = c.client.Task.WaitV2(ctx, clusterUUID, task.TaskUUID)
+	return err
+}
+
+// getV2 retrieves the v2 host, which reports hypervisor and CVM state
+func (c *HostClient) getV2(ctx context.Context, clusterUUID, uuid string) (*v2.Host, error) {
+	req, err := c.client.NewV2PERequest(ctx, http.MethodGet, clusterUUID, fmt.Sprintf(hostSinglePath, uuid), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	host := new(v2.Host)
+	err = c.client.Do(req, host)
+	return host, err
+}
+
+// waitForState polls the v2 host until done returns true or ctx is done
+func (c *HostClient) waitForState(ctx context.Context, clusterUUID, uuid string, done func(*v2.Host) bool) error {
+	ticker := time.NewTicker(taskPollInterval)
+	defer ticker.Stop()
+
+	for {
+		state, err := c.getV2(ctx, clusterUUID, uuid)
+		if err != nil {
+			return err
+		}
+		if done(state) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "host %s: hypervisor state %s, state %s", uuid, state.HypervisorState, state.State)
+		case <-ticker.C:
+		}
+	}
+}
+
+// hostAvailable reports whether the hypervisor and CVM of the host are up and not in maintenance mode
+func hostAvailable(state *v2.Host) bool {
+	return state.HypervisorState == hypervisorStateNormal && state.State == hostStateNormal &&
+		(state.HostInMaintenanceMode == nil || !*state.HostInMaintenanceMode)
+}
+
+func hostClusterUUID(host *schema.HostIntent) (string, error) {
+	if host.Status == nil || host.Status.ClusterReference == nil {
+		return "", fmt.Errorf("host %s has no cluster reference", host.Metadata.UUID)
+	}
+	return host.Status.ClusterReference.UUID, nil
+}
+
+// progressReporter returns a function reporting progress of host to fn, which may be nil
+func progressReporter(host *schema.HostIntent, fn func(*MaintenanceProgress)) func(phase, message string, item *BatchItem) {
+	return func(phase, message string, item *BatchItem) {
+		if fn == nil {
+			return
+		}
+		fn(&MaintenanceProgress{Host: host, Phase: phase, Message: message, Item: item})
+	}
+}
@@ -0,0 +1,107 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/pkg/utils"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+const (
+	identityProviderBasePath   = "/identity_providers"
+	identityProviderListPath   = identityProviderBasePath + "/list"
+	identityProviderSinglePath = identityProviderBasePath + "/%s"
+)
+
+// IdentityProviderClient is a client for the identity provider API.
+type IdentityProviderClient struct {
+	client *Client
+}
+
+// Get retrieves an identity provider by its UUID if the input can be parsed as an uuid, otherwise it
+// retrieves an identity provider by its name
+func (c *IdentityProviderClient) Get(ctx context.Context, idOrName string) (*schema.IdentityProviderIntent, error) {
+	if utils.IsValidUUID(idOrName) {
+		return c.GetByUUID(ctx, idOrName)
+	}
+	return c.GetByName(ctx, idOrName)
+}
+
+// GetByUUID retrieves an identity provider by its UUID
+func (c *IdentityProviderClient) GetByUUID(ctx context.Context, uuid string) (*schema.IdentityProviderIntent, error) {
+	response := new(schema.IdentityProviderIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(identityProviderSinglePath, uuid), http.MethodGet, nil, response)
+	return response, err
+}
+
+// GetByName retrieves an identity provider by its name
+func (c *IdentityProviderClient) GetByName(ctx context.Context, name string) (*schema.IdentityProviderIntent, error) {
+	list, err := c.List(ctx, &schema.DSMetadata{Filter: fmt.Sprintf("name==%s", name)})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Entities) == 0 {
+		return nil, fmt.Errorf("identity provider not found: %s", name)
+	}
+	return list.Entities[0], err
+}
+
+// List returns a list of identity providers
+func (c *IdentityProviderClient) List(ctx context.Context, opts *schema.DSMetadata) (*schema.IdentityProviderListIntent, error) {
+	response := new(schema.IdentityProviderListIntent)
+	err := c.client.requestHelper(ctx, identityProviderListPath, http.MethodPost, opts, response)
+	return response, err
+
+}
+
+// All returns all identity providers
+func (c *IdentityProviderClient) All(ctx context.Context) (*schema.IdentityProviderListIntent, error) {
+	return c.List(ctx, &schema.DSMetadata{Length: utils.Int64Ptr(itemsPerPage), Offset: utils.Int64Ptr(0)})
+}
+
+// Create creates an identity provider
+func (c *IdentityProviderClient) Create(ctx context.Context, createRequest *schema.IdentityProviderIntent) (*schema.IdentityProviderIntent, error) {
+	response := new(schema.IdentityProviderIntent)
+	err := c.client.requestHelper(ctx, identityProviderBasePath, http.MethodPost, createRequest, response)
+	return response, err
+}
+
+// Update an identity provider
+func (c *IdentityProviderClient) Update(ctx context.Context, identityProvider *schema.IdentityProviderIntent) (*schema.IdentityProviderIntent, error) {
+	response := new(schema.IdentityProviderIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(identityProviderSinglePath, identityProvider.Metadata.UUID), http.MethodPut, identityProvider.ToUpdateRequest(), response)
+	return response, err
+}
+
+// UpdateWith fetches the latest identity provider, applies mutate to it and updates it. On a
+// spec_version conflict the identity provider is fetched again and mutate is re-applied.
+// It returns the updated identity provider and the uuid of the update task.
+func (c *IdentityProviderClient) UpdateWith(ctx context.Context, uuid string, mutate func(*schema.IdentityProviderIntent) error) (*schema.IdentityProviderIntent, string, error) {
+	var response *schema.IdentityProviderIntent
+	err := c.client.retryOnConflict(ctx, func() error {
+		identityProvider, err := c.GetByUUID(ctx, uuid)
+		if err != nil {
+			return err
+		}
+		if err = mutate(identityProvider); err != nil {
+			return err
+		}
+		response, err = c.Update(ctx, identityProvider)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	var taskUUID string
+	if response.Status != nil {
+		taskUUID = response.Status.ExecutionContext.GetTaskUUID()
+	}
+	return response, taskUUID, nil
+}
+
+// Delete deletes an identity provider
+func (c *IdentityProviderClient) Delete(ctx context.Context, uuid string) error {
+	return c.client.requestHelper(ctx, fmt.Sprintf(identityProviderSinglePath, uuid), http.MethodDelete, nil, nil)
+}
@@ -93,12 +93,37 @@ func (c *ImageClient) Create(ctx context.Context, createRequest *schema.ImageInt
 
 // Update a image
 func (c *ImageClient) Update(ctx context.Context, image *schema.ImageIntent) (*schema.ImageIntent, error) {
-	image.Status = nil
 	response := new(schema.ImageIntent)
-	err := c.client.requestHelper(ctx, fmt.Sprintf(imageSinglePath, image.Metadata.UUID), http.MethodPut, image, response)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(imageSinglePath, image.Metadata.UUID), http.MethodPut, image.ToUpdateRequest(), response)
 	return response, err
 }
 
+// UpdateWith fetches the latest image, applies mutate to it and updates it. On a
+// spec_version conflict the image is fetched again and mutate is re-applied.
+// It returns the updated image and the uuid of the update task.
+func (c *ImageClient) UpdateWith(ctx context.Context, uuid string, mutate func(*schema.ImageIntent) error) (*schema.ImageIntent, string, error) {
+	var response *schema.ImageIntent
+	err := c.client.retryOnConflict(ctx, func() error {
+		image, err := c.GetByUUID(ctx, uuid)
+		if err != nil {
+			return err
+		}
+		if err = mutate(image); err != nil {
+			return err
+		}
+		response, err = c.Update(ctx, image)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	var taskUUID string
+	if response.Status != nil {
+		taskUUID = response.Status.ExecutionContext.GetTaskUUID()
+	}
+	return response, taskUUID, nil
+}
+
 // Delete deletes a image.
 func (c *ImageClient) Delete(ctx context.Context, uuid string) error {
 	return c.client.requestHelper(ctx, fmt.Sprintf(imageSinglePath, uuid), http.MethodDelete, nil, nil)
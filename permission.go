@@ -0,0 +1,62 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/pkg/utils"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+const (
+	permissionBasePath   = "/permissions"
+	permissionListPath   = permissionBasePath + "/list"
+	permissionSinglePath = permissionBasePath + "/%s"
+)
+
+// PermissionClient is a client for the permission API. Permissions are predefined and read only.
+type PermissionClient struct {
+	client *Client
+}
+
+// Get retrieves a permission by its UUID if the input can be parsed as an uuid, otherwise it
+// retrieves a permission by its name
+func (c *PermissionClient) Get(ctx context.Context, idOrName string) (*schema.PermissionIntent, error) {
+	if utils.IsValidUUID(idOrName) {
+		return c.GetByUUID(ctx, idOrName)
+	}
+	return c.GetByName(ctx, idOrName)
+}
+
+// GetByUUID retrieves a permission by its UUID
+func (c *PermissionClient) GetByUUID(ctx context.Context, uuid string) (*schema.PermissionIntent, error) {
+	response := new(schema.PermissionIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(permissionSinglePath, uuid), http.MethodGet, nil, response)
+	return response, err
+}
+
+// GetByName retrieves a permission by its name
+func (c *PermissionClient) GetByName(ctx context.Context, name string) (*schema.PermissionIntent, error) {
+	list, err := c.List(ctx, &schema.DSMetadata{Filter: fmt.Sprintf("name==%s", name)})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Entities) == 0 {
+		return nil, fmt.Errorf("permission not found: %s", name)
+	}
+	return list.Entities[0], err
+}
+
+// List returns a list of permissions
+func (c *PermissionClient) List(ctx context.Context, opts *schema.DSMetadata) (*schema.PermissionListIntent, error) {
+	response := new(schema.PermissionListIntent)
+	err := c.client.requestHelper(ctx, permissionListPath, http.MethodPost, opts, response)
+	return response, err
+
+}
+
+// All returns all permissions
+func (c *PermissionClient) All(ctx context.Context) (*schema.PermissionListIntent, error) {
+	return c.List(ctx, &schema.DSMetadata{Length: utils.Int64Ptr(itemsPerPage), Offset: utils.Int64Ptr(0)})
+}
@@ -0,0 +1,349 @@
+// Package placement recommends a cluster and host for new vms based on free capacity,
+// category affinity rules, project quotas and image availability.
+package placement
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	nutanix "github.com/tecbiz-ch/nutanix-go-sdk"
+	"github.com/tecbiz-ch/nutanix-go-sdk/pkg/utils"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+const (
+	kindImage   = "image"
+	kindCluster = "cluster"
+
+	// servicePrismCentral is listed in the service list of the prism central pseudo cluster
+	servicePrismCentral = "PRISM_CENTRAL"
+
+	// weights of the score components
+	memoryWeight   = 100
+	cpuWeight      = 50
+	affinityWeight = 25
+)
+
+// Request describes the vm to place and the placement rules
+type Request struct {
+	// VM is the vm to create. Its spec resources, disk images and project reference are used.
+	VM *schema.VMIntent
+
+	// ClusterUUIDs limits the candidates to these clusters. All clusters are candidates if empty.
+	ClusterUUIDs []string
+
+	// Affinity prefers hosts running vms with all of these categories
+	Affinity map[string]string
+
+	// AntiAffinity rejects hosts running vms with any of these categories
+	AntiAffinity map[string]string
+
+	// MaxVCPURatio rejects hosts whose vcpus per core would exceed it after placement. Zero disables the limit.
+	MaxVCPURatio float64
+
+	// ReserveN1 rejects clusters which would lose N+1 memory headroom
+	ReserveN1 bool
+}
+
+// Candidate is a host the vm fits on
+type Candidate struct {
+	ClusterUUID string
+	ClusterName string
+	HostUUID    string
+	HostName    string
+
+	// Score ranks the candidates, higher is better
+	Score float64
+
+	// FreeMemoryMib is the unallocated memory of the host after placement
+	FreeMemoryMib int64
+
+	// VCPURatio is the vcpus per core of the host after placement
+	VCPURatio float64
+
+	// AffinityMatches is the number of vms on the host matching the affinity categories
+	AffinityMatches int
+}
+
+// Rejection explains why a cluster or host is not a candidate. HostUUID is empty if the
+// whole cluster was rejected, ClusterUUID is empty if the request cannot be placed at all.
+type Rejection struct {
+	ClusterUUID string
+	ClusterName string
+	HostUUID    string
+	HostName    string
+	Reason      string
+}
+
+// Recommendation is the result of a placement request
+type Recommendation struct {
+	// Best is the highest scored candidate, nil if the vm fits nowhere
+	Best *Candidate
+
+	// Candidates sorted by score, best first
+	Candidates []*Candidate
+
+	Rejected []*Rejection
+}
+
+// Advisor recommends placements using a nutanix client
+type Advisor struct {
+	client *nutanix.Client
+}
+
+// NewAdvisor creates an advisor
+func NewAdvisor(client *nutanix.Client) *Advisor {
+	return &Advisor{client: client}
+}
+
+// Recommend scores every host of the candidate clusters for the vm of the request
+func (a *Advisor) Recommend(ctx context.Context, req *Request) (*Recommendation, error) {
+	if req.VM == nil || req.VM.Spec == nil || req.VM.Spec.Resources == nil {
+		return nil, fmt.Errorf("placement request has no vm spec resources")
+	}
+	resources := req.VM.Spec.Resources
+	vcpus := nutanix.VMVCPUs(resources)
+	recommendation := new(Recommendation)
+
+	if reason, err := a.checkQuota(ctx, req.VM); err != nil {
+		return nil, err
+	} else if reason != "" {
+		recommendation.Rejected = append(recommendation.Rejected, &Rejection{Reason: reason})
+		return recommendation, nil
+	}
+
+	imageClusters, err := a.imageClusters(ctx, resources)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters, err := a.client.Cluster.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// hosts and vms are listed once and shared by the summaries of all clusters
+	hosts, err := a.client.Host.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	vms, err := a.client.VM.ListAll(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	vmsByHost := groupByHost(vms)
+
+	for _, cluster := range clusters.Entities {
+		if isPrismCentral(cluster) || !selected(req.ClusterUUIDs, cluster.Metadata.UUID) {
+			continue
+		}
+		clusterName := ""
+		if cluster.Spec != nil {
+			clusterName = cluster.Spec.Name
+		}
+		reject := func(reason string) {
+			recommendation.Rejected = append(recommendation.Rejected, &Rejection{
+				ClusterUUID: cluster.Metadata.UUID,
+				ClusterName: clusterName,
+				Reason:      reason,
+			})
+		}
+
+		if image, ok := missingImage(imageClusters, cluster.Metadata.UUID); !ok {
+			reject(fmt.Sprintf("image %s is not placed on the cluster", image))
+			continue
+		}
+
+		summary := nutanix.SummarizeCluster(cluster, hosts.Entities, vms)
+		if req.ReserveN1 && summary.N1MemoryHeadroomMib < resources.MemorySizeMib {
+			reject(fmt.Sprintf("N+1 memory headroom of %d MiB is below the %d MiB required", summary.N1MemoryHeadroomMib, resources.MemorySizeMib))
+			continue
+		}
+
+		for _, host := range summary.Hosts {
+			candidate, reason := evaluate(req, summary, host, vcpus, vmsByHost[host.UUID])
+			if reason != "" {
+				recommendation.Rejected = append(recommendation.Rejected, &Rejection{
+					ClusterUUID: summary.UUID,
+					ClusterName: summary.Name,
+					HostUUID:    host.UUID,
+					HostName:    host.Name,
+					Reason:      reason,
+				})
+				continue
+			}
+			recommendation.Candidates = append(recommendation.Candidates, candidate)
+		}
+	}
+
+	sort.SliceStable(recommendation.Candidates, func(i, j int) bool {
+		return recommendation.Candidates[i].Score > recommendation.Candidates[j].Score
+	})
+	if len(recommendation.Candidates) > 0 {
+		recommendation.Best = recommendation.Candidates[0]
+	}
+	return recommendation, nil
+}
+
+// evaluate scores the host, or returns why the vm does not fit on it
+func evaluate(req *Request, summary *nutanix.ClusterSummary, host *nutanix.HostSummary, vcpus int64, vms []*schema.VMIntent) (*Candidate, string) {
+	memoryMib := req.VM.Spec.Resources.MemorySizeMib
+	if host.CPUCores == 0 || host.MemoryCapacityMib == 0 {
+		return nil, "host reports no cpu or memory capacity"
+	}
+
+	freeMib := host.MemoryCapacityMib - host.AllocatedMemoryMib - memoryMib
+	if freeMib < 0 {
+		return nil, fmt.Sprintf("not enough memory: %d MiB required, %d MiB free", memoryMib, host.MemoryCapacityMib-host.AllocatedMemoryMib)
+	}
+
+	vcpuRatio := float64(host.AllocatedVCPUs+vcpus) / float64(host.CPUCores)
+	if req.MaxVCPURatio > 0 && vcpuRatio > req.MaxVCPURatio {
+		return nil, fmt.Sprintf("vcpu ratio %.2f would exceed %.2f", vcpuRatio, req.MaxVCPURatio)
+	}
+
+	var affinityMatches int
+	for _, vm := range vms {
+		if vm.Metadata == nil {
+			continue
+		}
+		if key, value, ok := matchesAny(vm.Metadata.Categories, req.AntiAffinity); ok {
+			return nil, fmt.Sprintf("anti-affinity: vm %s has category %s:%s", vm.Metadata.UUID, key, value)
+		}
+		if len(req.Affinity) > 0 && matchesAll(vm.Metadata.Categories, req.Affinity) {
+			affinityMatches++
+		}
+	}
+
+	score := memoryWeight*float64(freeMib)/float64(host.MemoryCapacityMib) + cpuWeight/(1+vcpuRatio)
+	if affinityMatches > 0 {
+		score += affinityWeight
+	}
+
+	return &Candidate{
+		ClusterUUID:     summary.UUID,
+		ClusterName:     summary.Name,
+		HostUUID:        host.UUID,
+		HostName:        host.Name,
+		Score:           score,
+		FreeMemoryMib:   freeMib,
+		VCPURatio:       vcpuRatio,
+		AffinityMatches: affinityMatches,
+	}, ""
+}
+
+// checkQuota returns why the vm exceeds the quota of its project, empty if it fits
+func (a *Advisor) checkQuota(ctx context.Context, vm *schema.VMIntent) (string, error) {
+	if vm.Metadata == nil || vm.Metadata.ProjectReference == nil {
+		return "", nil
+	}
+	err := a.client.Project.CheckQuota(ctx, vm.Metadata.ProjectReference.UUID, vm)
+	var quotaErr *nutanix.QuotaError
+	if errors.As(err, &quotaErr) {
+		return quotaErr.Error(), nil
+	}
+	return "", err
+}
+
+// imageClusters returns the clusters each image of the vm disks is placed on. Images without
+// initial placement are available on every cluster and are not returned.
+func (a *Advisor) imageClusters(ctx context.Context, resources *schema.VMResources) (map[string]map[string]bool, error) {
+	imageClusters := make(map[string]map[string]bool)
+	for _, disk := range resources.DiskList {
+		if disk.DataSourceReference == nil || disk.DataSourceReference.Kind != kindImage {
+			continue
+		}
+		image, err := a.client.Image.GetByUUID(ctx, disk.DataSourceReference.UUID)
+		if err != nil {
+			return nil, err
+		}
+		if image.Spec == nil || image.Spec.Resources == nil || len(image.Spec.Resources.InitialPlacementRefList) == 0 {
+			continue
+		}
+		clusters := make(map[string]bool)
+		for _, ref := range image.Spec.Resources.InitialPlacementRefList {
+			if ref.Kind == kindCluster {
+				clusters[ref.UUID] = true
+			}
+		}
+		imageClusters[image.Spec.Name] = clusters
+	}
+	return imageClusters, nil
+}
+
+// groupByHost groups the vms by the host they run on
+func groupByHost(vms []*schema.VMIntent) map[string][]*schema.VMIntent {
+	byHost := make(map[string][]*schema.VMIntent)
+	for _, vm := range vms {
+		if vm.Status != nil && vm.Status.Resources != nil && vm.Status.Resources.HostReference != nil {
+			hostUUID := vm.Status.Resources.HostReference.UUID
+			byHost[hostUUID] = append(byHost[hostUUID], vm)
+		}
+	}
+	return byHost
+}
+
+// missingImage returns the first image by name not placed on the cluster, ok is false if there is one
+func missingImage(imageClusters map[string]map[string]bool, clusterUUID string) (string, bool) {
+	images := make([]string, 0, len(imageClusters))
+	for image := range imageClusters {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+	for _, image := range images {
+		if !imageClusters[image][clusterUUID] {
+			return image, false
+		}
+	}
+	return "", true
+}
+
+func isPrismCentral(cluster *schema.ClusterIntent) bool {
+	if cluster.Status == nil || cluster.Status.Resources == nil || cluster.Status.Resources.Config == nil {
+		return false
+	}
+	for _, service := range cluster.Status.Resources.Config.ServiceList {
+		if utils.StringValue(service) == servicePrismCentral {
+			return true
+		}
+	}
+	return false
+}
+
+func selected(uuids []string, uuid string) bool {
+	if len(uuids) == 0 {
+		return true
+	}
+	for _, u := range uuids {
+		if u == uuid {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAny returns the first rule by key the categories match
+func matchesAny(categories, rules map[string]string) (string, string, bool) {
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if v, ok := categories[key]; ok && v == rules[key] {
+			return key, rules[key], true
+		}
+	}
+	return "", "", false
+}
+
+func matchesAll(categories, rules map[string]string) bool {
+	for key, value := range rules {
+		if v, ok := categories[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
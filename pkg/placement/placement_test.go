@@ -0,0 +1,235 @@
+package placement
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	nutanix "github.com/tecbiz-ch/nutanix-go-sdk"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+const (
+	clusterUUID = "cluster-1"
+	otherUUID   = "cluster-2"
+)
+
+func host(uuid, cluster string, cores int, memoryMib uint64) *schema.HostIntent {
+	return &schema.HostIntent{
+		Metadata: &schema.Metadata{UUID: uuid},
+		Status: &schema.HostDefStatus{
+			Name:             uuid,
+			ClusterReference: &schema.Reference{Kind: kindCluster, UUID: cluster},
+			Resources:        &schema.HostResources{NumCPUSockets: 1, NumCPUCores: cores, MemoryCapacityMib: memoryMib},
+		},
+	}
+}
+
+func vm(uuid, cluster, hostUUID, powerState string, vcpus, memoryMib int64, categories map[string]string) *schema.VMIntent {
+	return &schema.VMIntent{
+		Metadata: &schema.Metadata{UUID: uuid, Categories: categories},
+		Spec: &schema.VM{
+			Name:             uuid,
+			ClusterReference: &schema.Reference{Kind: kindCluster, UUID: cluster},
+			Resources: &schema.VMResources{
+				NumSockets:    vcpus,
+				MemorySizeMib: memoryMib,
+				PowerState:    powerState,
+			},
+		},
+		Status: &schema.VMDefStatus{
+			Resources: &schema.VMResourcesDefStatus{HostReference: &schema.Reference{Kind: "host", UUID: hostUUID}},
+		},
+	}
+}
+
+func TestSummarizeCluster(t *testing.T) {
+	cluster := &schema.ClusterIntent{
+		Metadata: &schema.Metadata{UUID: clusterUUID},
+		Spec:     &schema.Cluster{Name: "cluster"},
+	}
+	hosts := []*schema.HostIntent{
+		host("host-1", clusterUUID, 10, 1000),
+		host("host-2", clusterUUID, 20, 2000),
+		host("host-3", otherUUID, 40, 4000),
+	}
+	vms := []*schema.VMIntent{
+		vm("vm-1", clusterUUID, "host-1", "ON", 4, 100, nil),
+		vm("vm-2", clusterUUID, "host-2", "ON", 2, 200, nil),
+		vm("vm-3", clusterUUID, "host-2", "OFF", 8, 800, nil),
+		vm("vm-4", otherUUID, "host-3", "ON", 16, 1600, nil),
+	}
+
+	summary := nutanix.SummarizeCluster(cluster, hosts, vms)
+
+	if summary.UUID != clusterUUID || summary.Name != "cluster" {
+		t.Errorf("cluster = %s %s, want %s cluster", summary.UUID, summary.Name, clusterUUID)
+	}
+	var hostUUIDs []string
+	for _, h := range summary.Hosts {
+		hostUUIDs = append(hostUUIDs, h.UUID)
+	}
+	if !reflect.DeepEqual(hostUUIDs, []string{"host-1", "host-2"}) {
+		t.Errorf("hosts = %v, want [host-1 host-2]", hostUUIDs)
+	}
+	if summary.CPUCores != 30 || summary.MemoryCapacityMib != 3000 {
+		t.Errorf("capacity = %d cores %d MiB, want 30 cores 3000 MiB", summary.CPUCores, summary.MemoryCapacityMib)
+	}
+	if summary.AllocatedVCPUs != 6 || summary.AllocatedMemoryMib != 300 {
+		t.Errorf("allocated = %d vcpus %d MiB, want 6 vcpus 300 MiB", summary.AllocatedVCPUs, summary.AllocatedMemoryMib)
+	}
+	if h := summary.Hosts[1]; h.AllocatedVCPUs != 2 || h.AllocatedMemoryMib != 200 || h.NumVMs != 1 {
+		t.Errorf("host-2 allocated = %d vcpus %d MiB %d vms, want 2 vcpus 200 MiB 1 vm", h.AllocatedVCPUs, h.AllocatedMemoryMib, h.NumVMs)
+	}
+	if summary.N1MemoryHeadroomMib != 700 {
+		t.Errorf("N+1 memory headroom = %d MiB, want 700 MiB", summary.N1MemoryHeadroomMib)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	summary := &nutanix.ClusterSummary{UUID: clusterUUID, Name: "cluster"}
+	hostSummary := func(cores, memoryMib, allocatedVCPUs, allocatedMib int64) *nutanix.HostSummary {
+		return &nutanix.HostSummary{
+			UUID:               "host-1",
+			Name:               "host-1",
+			CPUCores:           cores,
+			MemoryCapacityMib:  memoryMib,
+			AllocatedVCPUs:     allocatedVCPUs,
+			AllocatedMemoryMib: allocatedMib,
+		}
+	}
+	request := func(memoryMib int64, maxVCPURatio float64, affinity, antiAffinity map[string]string) *Request {
+		return &Request{
+			VM:           vm("new", clusterUUID, "", "ON", 2, memoryMib, nil),
+			MaxVCPURatio: maxVCPURatio,
+			Affinity:     affinity,
+			AntiAffinity: antiAffinity,
+		}
+	}
+	web := map[string]string{"AppType": "web"}
+
+	tests := []struct {
+		name     string
+		req      *Request
+		host     *nutanix.HostSummary
+		vms      []*schema.VMIntent
+		reason   string
+		free     int64
+		ratio    float64
+		matches  int
+		minScore float64
+	}{
+		{
+			name:   "no capacity",
+			req:    request(100, 0, nil, nil),
+			host:   hostSummary(0, 1000, 0, 0),
+			reason: "no cpu or memory capacity",
+		},
+		{
+			name:   "not enough memory",
+			req:    request(600, 0, nil, nil),
+			host:   hostSummary(10, 1000, 0, 500),
+			reason: "not enough memory: 600 MiB required, 500 MiB free",
+		},
+		{
+			name:   "vcpu ratio exceeded",
+			req:    request(100, 1, nil, nil),
+			host:   hostSummary(10, 1000, 9, 0),
+			reason: "vcpu ratio 1.10 would exceed 1.00",
+		},
+		{
+			name: "anti-affinity",
+			req:  request(100, 0, nil, map[string]string{"AppType": "web", "Environment": "prod"}),
+			host: hostSummary(10, 1000, 0, 0),
+			vms: []*schema.VMIntent{
+				vm("vm-1", clusterUUID, "host-1", "ON", 1, 100, map[string]string{"AppType": "web", "Environment": "prod"}),
+			},
+			reason: "anti-affinity: vm vm-1 has category AppType:web",
+		},
+		{
+			name:  "fits",
+			req:   request(100, 0, nil, nil),
+			host:  hostSummary(10, 1000, 8, 400),
+			free:  500,
+			ratio: 1,
+		},
+		{
+			name: "affinity matches",
+			req:  request(100, 0, web, nil),
+			host: hostSummary(10, 1000, 0, 0),
+			vms: []*schema.VMIntent{
+				vm("vm-1", clusterUUID, "host-1", "ON", 1, 100, web),
+				vm("vm-2", clusterUUID, "host-1", "ON", 1, 100, nil),
+			},
+			free:     900,
+			ratio:    0.2,
+			matches:  1,
+			minScore: affinityWeight,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidate, reason := evaluate(tt.req, summary, tt.host, nutanix.VMVCPUs(tt.req.VM.Spec.Resources), tt.vms)
+			if tt.reason != "" {
+				if candidate != nil || !strings.Contains(reason, tt.reason) {
+					t.Fatalf("reason = %q, want %q", reason, tt.reason)
+				}
+				return
+			}
+			if candidate == nil {
+				t.Fatalf("unexpected rejection: %s", reason)
+			}
+			if candidate.ClusterUUID != clusterUUID || candidate.HostUUID != "host-1" {
+				t.Errorf("candidate = %s/%s, want %s/host-1", candidate.ClusterUUID, candidate.HostUUID, clusterUUID)
+			}
+			if candidate.FreeMemoryMib != tt.free {
+				t.Errorf("free memory = %d MiB, want %d MiB", candidate.FreeMemoryMib, tt.free)
+			}
+			if candidate.VCPURatio != tt.ratio {
+				t.Errorf("vcpu ratio = %.2f, want %.2f", candidate.VCPURatio, tt.ratio)
+			}
+			if candidate.AffinityMatches != tt.matches {
+				t.Errorf("affinity matches = %d, want %d", candidate.AffinityMatches, tt.matches)
+			}
+			if candidate.Score <= tt.minScore {
+				t.Errorf("score = %.2f, want more than %.2f", candidate.Score, tt.minScore)
+			}
+		})
+	}
+}
+
+func TestMissingImage(t *testing.T) {
+	imageClusters := map[string]map[string]bool{
+		"ubuntu":  {clusterUUID: true},
+		"windows": {otherUUID: true},
+		"centos":  {otherUUID: true},
+		"debian":  {clusterUUID: true, otherUUID: true},
+	}
+
+	tests := []struct {
+		name          string
+		imageClusters map[string]map[string]bool
+		cluster       string
+		image         string
+		ok            bool
+	}{
+		{name: "no images", cluster: clusterUUID, ok: true},
+		{name: "first missing by name", imageClusters: imageClusters, cluster: clusterUUID, image: "centos"},
+		{name: "single missing", imageClusters: imageClusters, cluster: otherUUID, image: "ubuntu"},
+		{name: "unknown cluster", imageClusters: imageClusters, cluster: "cluster-3", image: "centos"},
+		{name: "all placed", imageClusters: map[string]map[string]bool{"debian": imageClusters["debian"]}, cluster: otherUUID, ok: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// maps are iterated in random order, repeat to catch nondeterministic results
+			for i := 0; i < 20; i++ {
+				image, ok := missingImage(tt.imageClusters, tt.cluster)
+				if image != tt.image || ok != tt.ok {
+					t.Fatalf("missingImage = %q, %v, want %q, %v", image, ok, tt.image, tt.ok)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	nutanix "github.com/tecbiz-ch/nutanix-go-sdk"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+// Engine computes and applies retention plans using a nutanix client
+type Engine struct {
+	client      *nutanix.Client
+	policy      *Policy
+	concurrency int
+}
+
+// Result of applying a plan
+type Result struct {
+	Deleted []*Item
+	Failed  []*Failure
+}
+
+// Failure of a single delete
+type Failure struct {
+	Item *Item
+	Err  error
+}
+
+// NewEngine creates an engine which deletes at most concurrency items in parallel
+func NewEngine(client *nutanix.Client, policy *Policy, concurrency int) *Engine {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &Engine{client: client, policy: policy, concurrency: concurrency}
+}
+
+// PlanRecoveryPoints lists the vm recovery points matching opts and applies the policy to the
+// recovery points of each vm separately. Recovery points without a parent vm are skipped.
+func (e *Engine) PlanRecoveryPoints(ctx context.Context, opts *schema.DSMetadata) (*Plan, error) {
+	list, err := e.client.VMRecoveryPoint.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := new(Plan)
+	byVM := make(map[string][]*Item)
+	var order []string
+	for _, rp := range list.Entities {
+		if rp.Status == nil || rp.Status.Resources == nil {
+			continue
+		}
+		resources := rp.Status.Resources
+		item := &Item{
+			UUID:           rp.Metadata.UUID,
+			Name:           rp.Status.Name,
+			ExpirationTime: resources.ExpirationTime,
+			RecoveryPoint:  rp,
+		}
+		if resources.CreationTime != nil {
+			item.CreationTime = *resources.CreationTime
+		}
+
+		if resources.ParentVMReference == nil || resources.ParentVMReference.UUID == "" {
+			plan.Skipped = append(plan.Skipped, item)
+			continue
+		}
+		vmUUID := resources.ParentVMReference.UUID
+		if _, ok := byVM[vmUUID]; !ok {
+			order = append(order, vmUUID)
+		}
+		byVM[vmUUID] = append(byVM[vmUUID], item)
+	}
+
+	now := time.Now()
+	for _, vmUUID := range order {
+		plan.merge(e.policy.Apply(byVM[vmUUID], now))
+	}
+	return plan, nil
+}
+
+// PlanSnapshots lists the v2 snapshots of vm and applies the policy to them
+func (e *Engine) PlanSnapshots(ctx context.Context, vm *schema.VMIntent) (*Plan, error) {
+	list, err := e.client.Snapshot.ListByVM(ctx, vm)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*Item, 0, len(list.Entities))
+	for _, snapshot := range list.Entities {
+		if snapshot.Deleted {
+			continue
+		}
+		item := &Item{
+			UUID:     snapshot.UUID,
+			Name:     snapshot.Name,
+			Snapshot: snapshot,
+			vm:       vm,
+		}
+		if snapshot.CreatedTime != nil {
+			item.CreationTime = time.Time(*snapshot.CreatedTime)
+		}
+		items = append(items, item)
+	}
+	return e.policy.Apply(items, time.Now()), nil
+}
+
+// Apply deletes the items of the plan marked for deletion, waiting for each delete task.
+// Failed deletes do not stop the remaining deletes and are reported in the result.
+func (e *Engine) Apply(ctx context.Context, plan *Plan) *Result {
+	result := new(Result)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, e.concurrency)
+
+	for _, item := range plan.Delete {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(item *Item) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := e.delete(ctx, item)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Failed = append(result.Failed, &Failure{Item: item, Err: err})
+				return
+			}
+			result.Deleted = append(result.Deleted, item)
+		}(item)
+	}
+	wg.Wait()
+	return result
+}
+
+func (e *Engine) delete(ctx context.Context, item *Item) error {
+	if item.RecoveryPoint != nil {
+		return e.client.VMRecoveryPoint.DeleteAndWait(ctx, item.RecoveryPoint)
+	}
+
+	task, err := e.client.Snapshot.Delete(ctx, item.vm, item.Snapshot)
+	if err != nil {
+		return err
+	}
+	if task.TaskUUID == "" {
+		return fmt.Errorf("delete of snapshot %s returned no task", item.UUID)
+	}
+	_, err = e.client.Task.WaitV2(ctx, item.vm.Spec.ClusterReference.UUID, task.TaskUUID)
+	return err
+}
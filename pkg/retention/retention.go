@@ -0,0 +1,156 @@
+// Package retention computes which vm recovery points and snapshots to keep based on a
+// retention policy and deletes the others.
+package retention
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+	v2 "github.com/tecbiz-ch/nutanix-go-sdk/schema/v2"
+)
+
+// Policy describes which items are kept. An item is kept if it is selected by KeepLast or
+// by one of the bucket rules. If none of those rules is set, every item is selected.
+// Selected items are then still deleted if they expired or exceed MaxAge.
+type Policy struct {
+	// KeepLast keeps the n most recent items
+	KeepLast int
+
+	// KeepHourly keeps the most recent item of each of the last n hours that have items
+	KeepHourly int
+
+	// KeepDaily keeps the most recent item of each of the last n days that have items
+	KeepDaily int
+
+	// KeepWeekly keeps the most recent item of each of the last n ISO weeks that have items
+	KeepWeekly int
+
+	// KeepMonthly keeps the most recent item of each of the last n months that have items
+	KeepMonthly int
+
+	// MaxAge deletes items older than MaxAge, even if they are selected by another rule.
+	// Zero disables the limit.
+	MaxAge time.Duration
+
+	// HonorExpirationTime keeps items until their expiration time and deletes them once
+	// it has passed. Items without an expiration time are not affected.
+	HonorExpirationTime bool
+}
+
+// Item is a recovery point or snapshot the policy is applied to
+type Item struct {
+	UUID           string
+	Name           string
+	CreationTime   time.Time
+	ExpirationTime *time.Time
+
+	// RecoveryPoint is set if the item is a v3 vm recovery point
+	RecoveryPoint *schema.VMRecoveryPointIntent
+
+	// Snapshot is set if the item is a v2 snapshot
+	Snapshot *v2.SnapshotSpec
+
+	// vm the snapshot belongs to, required to delete it through the v2 API
+	vm *schema.VMIntent
+}
+
+// Plan is the result of applying a policy to a set of items
+type Plan struct {
+	Keep   []*Item
+	Delete []*Item
+
+	// Skipped items the policy was not applied to, for example recovery points without a
+	// parent vm. They are neither kept nor deleted.
+	Skipped []*Item
+}
+
+// Apply computes which of the items to keep and which to delete at the given time
+func (p *Policy) Apply(items []*Item, now time.Time) *Plan {
+	sorted := make([]*Item, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreationTime.After(sorted[j].CreationTime)
+	})
+
+	keep := make(map[*Item]bool)
+	if p.KeepLast == 0 && p.KeepHourly == 0 && p.KeepDaily == 0 && p.KeepWeekly == 0 && p.KeepMonthly == 0 {
+		for _, item := range sorted {
+			keep[item] = true
+		}
+	}
+
+	for i := 0; i < p.KeepLast && i < len(sorted); i++ {
+		keep[sorted[i]] = true
+	}
+
+	keepBuckets(sorted, keep, p.KeepHourly, func(t time.Time) string { return t.Format("2006-01-02 15") })
+	keepBuckets(sorted, keep, p.KeepDaily, func(t time.Time) string { return t.Format("2006-01-02") })
+	keepBuckets(sorted, keep, p.KeepWeekly, func(t time.Time) string {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-%02d", year, week)
+	})
+	keepBuckets(sorted, keep, p.KeepMonthly, func(t time.Time) string { return t.Format("2006-01") })
+
+	for _, item := range sorted {
+		if p.HonorExpirationTime && item.ExpirationTime != nil {
+			keep[item] = item.ExpirationTime.After(now)
+		}
+		if p.MaxAge > 0 && now.Sub(item.CreationTime) > p.MaxAge {
+			keep[item] = false
+		}
+	}
+
+	plan := new(Plan)
+	for _, item := range sorted {
+		if keep[item] {
+			plan.Keep = append(plan.Keep, item)
+		} else {
+			plan.Delete = append(plan.Delete, item)
+		}
+	}
+	return plan
+}
+
+// keepBuckets keeps the most recent item of each of the first n buckets. Items must be
+// sorted newest first.
+func keepBuckets(items []*Item, keep map[*Item]bool, n int, bucket func(time.Time) string) {
+	var last string
+	for _, item := range items {
+		if n <= 0 {
+			return
+		}
+		key := bucket(item.CreationTime.UTC())
+		if key == last {
+			continue
+		}
+		keep[item] = true
+		last = key
+		n--
+	}
+}
+
+func (p *Plan) merge(other *Plan) {
+	p.Keep = append(p.Keep, other.Keep...)
+	p.Delete = append(p.Delete, other.Delete...)
+	p.Skipped = append(p.Skipped, other.Skipped...)
+}
+
+// WriteTo writes a human readable dry-run of the plan to w
+func (p *Plan) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	for _, item := range p.Keep {
+		fmt.Fprintf(&buf, "keep    %s  %s  %s\n", item.CreationTime.UTC().Format(time.RFC3339), item.UUID, item.Name)
+	}
+	for _, item := range p.Delete {
+		fmt.Fprintf(&buf, "delete  %s  %s  %s\n", item.CreationTime.UTC().Format(time.RFC3339), item.UUID, item.Name)
+	}
+	for _, item := range p.Skipped {
+		fmt.Fprintf(&buf, "skip    %s  %s  %s\n", item.CreationTime.UTC().Format(time.RFC3339), item.UUID, item.Name)
+	}
+	fmt.Fprintf(&buf, "%d to keep, %d to delete, %d skipped\n", len(p.Keep), len(p.Delete), len(p.Skipped))
+	return buf.WriteTo(w)
+}
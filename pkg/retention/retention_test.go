@@ -0,0 +1,210 @@
+package retention
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2022, 3, 16, 12, 0, 0, 0, time.UTC)
+
+func item(uuid string, created time.Time) *Item {
+	return &Item{UUID: uuid, CreationTime: created}
+}
+
+func expiring(uuid string, created, expires time.Time) *Item {
+	return &Item{UUID: uuid, CreationTime: created, ExpirationTime: &expires}
+}
+
+func uuids(items []*Item) []string {
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.UUID)
+	}
+	return result
+}
+
+func TestPolicyApply(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+		items  []*Item
+		keep   []string
+		delete []string
+	}{
+		{
+			name:   "empty input",
+			policy: Policy{KeepLast: 3, KeepDaily: 7},
+			keep:   []string{},
+			delete: []string{},
+		},
+		{
+			name:   "no rules keeps everything",
+			policy: Policy{},
+			items: []*Item{
+				item("a", now.Add(-48*time.Hour)),
+				item("b", now.Add(-time.Hour)),
+			},
+			keep:   []string{"b", "a"},
+			delete: []string{},
+		},
+		{
+			name:   "keep last",
+			policy: Policy{KeepLast: 2},
+			items: []*Item{
+				item("a", now.Add(-3*time.Hour)),
+				item("b", now.Add(-time.Hour)),
+				item("c", now.Add(-2*time.Hour)),
+				item("d", now.Add(-4*time.Hour)),
+			},
+			keep:   []string{"b", "c"},
+			delete: []string{"a", "d"},
+		},
+		{
+			name:   "keep last larger than input",
+			policy: Policy{KeepLast: 5},
+			items: []*Item{
+				item("a", now.Add(-time.Hour)),
+				item("b", now.Add(-2*time.Hour)),
+			},
+			keep:   []string{"a", "b"},
+			delete: []string{},
+		},
+		{
+			name:   "keep last ties keep input order",
+			policy: Policy{KeepLast: 1},
+			items: []*Item{
+				item("a", now.Add(-time.Hour)),
+				item("b", now.Add(-time.Hour)),
+			},
+			keep:   []string{"a"},
+			delete: []string{"b"},
+		},
+		{
+			name:   "hourly keeps the newest item of each hour",
+			policy: Policy{KeepHourly: 2},
+			items: []*Item{
+				item("a", now.Add(-10*time.Minute)),
+				item("b", now.Add(-20*time.Minute)),
+				item("c", now.Add(-70*time.Minute)),
+				item("d", now.Add(-80*time.Minute)),
+				item("e", now.Add(-130*time.Minute)),
+			},
+			keep:   []string{"a", "c"},
+			delete: []string{"b", "d", "e"},
+		},
+		{
+			name:   "hourly ties in the same hour keep one",
+			policy: Policy{KeepHourly: 1},
+			items: []*Item{
+				item("a", now.Add(-10*time.Minute)),
+				item("b", now.Add(-10*time.Minute)),
+			},
+			keep:   []string{"a"},
+			delete: []string{"b"},
+		},
+		{
+			name:   "daily skips days without items",
+			policy: Policy{KeepDaily: 2},
+			items: []*Item{
+				item("a", now.Add(-time.Hour)),
+				item("b", now.Add(-2*time.Hour)),
+				item("c", now.Add(-72*time.Hour)),
+				item("d", now.Add(-96*time.Hour)),
+			},
+			keep:   []string{"a", "c"},
+			delete: []string{"b", "d"},
+		},
+		{
+			name:   "weekly uses iso weeks",
+			policy: Policy{KeepWeekly: 2},
+			items: []*Item{
+				// wednesday and monday of week 11, sunday of week 10, monday of week 10
+				item("a", now),
+				item("b", now.Add(-48*time.Hour)),
+				item("c", now.Add(-72*time.Hour)),
+				item("d", now.Add(-9*24*time.Hour)),
+			},
+			keep:   []string{"a", "c"},
+			delete: []string{"b", "d"},
+		},
+		{
+			name:   "rules are combined",
+			policy: Policy{KeepLast: 1, KeepDaily: 2},
+			items: []*Item{
+				item("a", now.Add(-time.Hour)),
+				item("b", now.Add(-2*time.Hour)),
+				item("c", now.Add(-25*time.Hour)),
+				item("d", now.Add(-26*time.Hour)),
+			},
+			keep:   []string{"a", "c"},
+			delete: []string{"b", "d"},
+		},
+		{
+			name:   "max age deletes selected items",
+			policy: Policy{KeepLast: 3, MaxAge: 24 * time.Hour},
+			items: []*Item{
+				item("a", now.Add(-time.Hour)),
+				item("b", now.Add(-24*time.Hour)),
+				item("c", now.Add(-25*time.Hour)),
+			},
+			keep:   []string{"a", "b"},
+			delete: []string{"c"},
+		},
+		{
+			name:   "expiration time keeps unexpired and deletes expired items",
+			policy: Policy{KeepLast: 1, HonorExpirationTime: true},
+			items: []*Item{
+				expiring("a", now.Add(-time.Hour), now.Add(-time.Minute)),
+				expiring("b", now.Add(-2*time.Hour), now.Add(time.Hour)),
+				item("c", now.Add(-3*time.Hour)),
+				expiring("d", now.Add(-4*time.Hour), now),
+			},
+			keep:   []string{"b"},
+			delete: []string{"a", "c", "d"},
+		},
+		{
+			name:   "expiration time is ignored unless honored",
+			policy: Policy{KeepLast: 1},
+			items: []*Item{
+				expiring("a", now.Add(-time.Hour), now.Add(-time.Minute)),
+				expiring("b", now.Add(-2*time.Hour), now.Add(time.Hour)),
+			},
+			keep:   []string{"a"},
+			delete: []string{"b"},
+		},
+		{
+			name:   "max age wins over expiration time",
+			policy: Policy{HonorExpirationTime: true, MaxAge: time.Hour},
+			items: []*Item{
+				expiring("a", now.Add(-2*time.Hour), now.Add(time.Hour)),
+			},
+			keep:   []string{},
+			delete: []string{"a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := tt.policy.Apply(tt.items, now)
+			if keep := uuids(plan.Keep); !reflect.DeepEqual(keep, tt.keep) {
+				t.Errorf("keep = %v, want %v", keep, tt.keep)
+			}
+			if del := uuids(plan.Delete); !reflect.DeepEqual(del, tt.delete) {
+				t.Errorf("delete = %v, want %v", del, tt.delete)
+			}
+		})
+	}
+}
+
+func TestPolicyApplyDoesNotModifyItems(t *testing.T) {
+	items := []*Item{
+		item("a", now.Add(-2*time.Hour)),
+		item("b", now.Add(-time.Hour)),
+	}
+	policy := Policy{KeepLast: 1}
+	policy.Apply(items, now)
+	if got := uuids(items); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("items reordered to %v", got)
+	}
+}
@@ -0,0 +1,152 @@
+package routingpolicy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	nutanix "github.com/tecbiz-ch/nutanix-go-sdk"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+// Manager plans and applies routing policy changes using a nutanix client
+type Manager struct {
+	client *nutanix.Client
+}
+
+// NewManager creates a manager
+func NewManager(client *nutanix.Client) *Manager {
+	return &Manager{client: client}
+}
+
+// Policies returns the routing policies of the vpc in evaluation order
+func (m *Manager) Policies(ctx context.Context, vpcUUID string) ([]*schema.RoutingPolicyIntent, error) {
+	list, err := m.client.RoutingPolicy.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var policies []*schema.RoutingPolicyIntent
+	for _, policy := range list.Entities {
+		if policy.Spec == nil || policy.Spec.Resources == nil {
+			continue
+		}
+		resources := policy.Spec.Resources
+		if (resources.VpcReference != nil && resources.VpcReference.UUID == vpcUUID) ||
+			(resources.VirtualNetworkReference != nil && resources.VirtualNetworkReference.UUID == vpcUUID) {
+			policies = append(policies, policy)
+		}
+	}
+	return Sort(policies), nil
+}
+
+// Plan allocates a priority for the policy at position and finds the policies it shadows or
+// conflicts with. Nothing is changed, the plan is applied with Apply. The policy is not modified.
+func (m *Manager) Plan(ctx context.Context, vpcUUID string, policy *schema.RoutingPolicyIntent, position *Position) (*Plan, error) {
+	if policy.Spec == nil || policy.Spec.Resources == nil {
+		return nil, fmt.Errorf("routing policy has no spec resources")
+	}
+	policies, err := m.Policies(ctx, vpcUUID)
+	if err != nil {
+		return nil, err
+	}
+	priority, renumber, err := Allocate(policies, position)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := *policy.Spec
+	resources := *spec.Resources
+	resources.Priority = priority
+	if resources.VpcReference == nil {
+		resources.VpcReference = &schema.Reference{Kind: "vpc", UUID: vpcUUID}
+	}
+	spec.Resources = &resources
+	created := &schema.RoutingPolicyIntent{
+		APIVersion: policy.APIVersion,
+		Metadata:   policy.Metadata,
+		Spec:       &spec,
+	}
+	if created.Metadata == nil {
+		created.Metadata = &schema.Metadata{Kind: "routing_policy"}
+	}
+
+	// findings are computed on the policies as they are after applying the plan
+	after := make([]*schema.RoutingPolicyIntent, 0, len(policies)+1)
+	renumbered := make(map[*schema.RoutingPolicyIntent]int16, len(renumber))
+	for _, r := range renumber {
+		renumbered[r.Policy] = r.To
+	}
+	for _, p := range policies {
+		if to, ok := renumbered[p]; ok {
+			p = withPriority(p, to)
+		}
+		after = append(after, p)
+	}
+	after = append(after, created)
+
+	plan := &Plan{VpcUUID: vpcUUID, Policy: created, Renumber: renumber}
+	for _, finding := range Analyze(after) {
+		if finding.Policy == created || finding.By == created {
+			plan.Findings = append(plan.Findings, finding)
+		}
+	}
+	return plan, nil
+}
+
+// Apply renumbers the existing policies and creates the policy of the plan, waiting for each
+// task. It fails if a renumbered policy changed its priority since the plan was computed.
+func (m *Manager) Apply(ctx context.Context, plan *Plan) (*schema.RoutingPolicyIntent, error) {
+	// move the policy farthest from the new policy first, so every target priority is free
+	renumber := make([]*Renumbering, len(plan.Renumber))
+	copy(renumber, plan.Renumber)
+	sort.SliceStable(renumber, func(i, j int) bool {
+		if renumber[i].To < renumber[i].From {
+			return renumber[i].To < renumber[j].To
+		}
+		return renumber[i].To > renumber[j].To
+	})
+
+	for _, r := range renumber {
+		uuid := r.Policy.Metadata.UUID
+		_, taskUUID, err := m.client.RoutingPolicy.UpdateWith(ctx, uuid, func(policy *schema.RoutingPolicyIntent) error {
+			if Priority(policy) != r.From {
+				return fmt.Errorf("priority of routing policy %s changed from %d to %d since the plan was computed", uuid, r.From, Priority(policy))
+			}
+			policy.Spec.Resources.Priority = r.To
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err = m.wait(ctx, taskUUID); err != nil {
+			return nil, err
+		}
+	}
+
+	created, err := m.client.RoutingPolicy.Create(ctx, plan.Policy)
+	if err != nil {
+		return nil, err
+	}
+	if created.Status != nil {
+		if err = m.wait(ctx, created.Status.ExecutionContext.GetTaskUUID()); err != nil {
+			return nil, err
+		}
+	}
+	return m.client.RoutingPolicy.GetByUUID(ctx, created.Metadata.UUID)
+}
+
+func (m *Manager) wait(ctx context.Context, taskUUID string) error {
+	_, err := m.client.Task.Wait(ctx, taskUUID)
+	return err
+}
+
+// withPriority returns a copy of the policy with another spec priority
+func withPriority(policy *schema.RoutingPolicyIntent, priority int16) *schema.RoutingPolicyIntent {
+	spec := *policy.Spec
+	resources := *spec.Resources
+	resources.Priority = priority
+	spec.Resources = &resources
+	result := *policy
+	result.Spec = &spec
+	return &result
+}
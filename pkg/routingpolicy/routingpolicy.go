@@ -0,0 +1,549 @@
+// Package routingpolicy allocates priorities of vpc routing policies and detects shadowed and
+// conflicting policies. Policies with a higher priority are evaluated first.
+package routingpolicy
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+const (
+	// Shadowed policies never match because a policy evaluated earlier matches all of their traffic
+	Shadowed FindingType = "shadowed"
+
+	// Conflicting policies match common traffic with different actions. The policy evaluated
+	// earlier wins for the common traffic.
+	Conflict FindingType = "conflict"
+
+	minPort = 0
+	maxPort = 65535
+)
+
+// privatePrefixes are the private ipv4 ranges and the ipv6 unique local addresses
+var privatePrefixes = []*net.IPNet{
+	{IP: net.IPv4(10, 0, 0, 0).To4(), Mask: net.CIDRMask(8, 32)},
+	{IP: net.IPv4(172, 16, 0, 0).To4(), Mask: net.CIDRMask(12, 32)},
+	{IP: net.IPv4(192, 168, 0, 0).To4(), Mask: net.CIDRMask(16, 32)},
+	{IP: net.ParseIP("fc00::"), Mask: net.CIDRMask(7, 128)},
+}
+
+// Position places a new policy. Before and After are uuids of policies of the vpc, the new
+// policy is evaluated right before or right after that policy. If neither is set, Priority is
+// used. If Priority is zero too, the new policy is evaluated after all others.
+type Position struct {
+	Before   string
+	After    string
+	Priority int16
+}
+
+// Renumbering changes the priority of an existing policy to make room for a new policy
+type Renumbering struct {
+	Policy *schema.RoutingPolicyIntent
+	From   int16
+	To     int16
+}
+
+// FindingType is Shadowed or Conflict
+type FindingType string
+
+// Finding is a problem between two policies of a vpc
+type Finding struct {
+	Type FindingType
+
+	// Policy is evaluated after By
+	Policy *schema.RoutingPolicyIntent
+	By     *schema.RoutingPolicyIntent
+}
+
+func (f *Finding) String() string {
+	if f.Type == Shadowed {
+		return fmt.Sprintf("%s is shadowed by %s", describe(f.Policy), describe(f.By))
+	}
+	return fmt.Sprintf("%s conflicts with %s", describe(f.Policy), describe(f.By))
+}
+
+// Plan is the dry-run of adding a policy to a vpc
+type Plan struct {
+	VpcUUID string
+
+	// Policy is the policy to create with the allocated priority
+	Policy *schema.RoutingPolicyIntent
+
+	// Renumber are the priority changes of existing policies, applied before the policy is created
+	Renumber []*Renumbering
+
+	// Findings are the shadowed and conflicting policies involving the new policy
+	Findings []*Finding
+}
+
+// WriteTo writes a human readable dry-run of the plan to w
+func (p *Plan) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	for _, r := range p.Renumber {
+		fmt.Fprintf(&buf, "renumber  %s  %d -> %d\n", describe(r.Policy), r.From, r.To)
+	}
+	fmt.Fprintf(&buf, "create    %s  %d\n", describe(p.Policy), Priority(p.Policy))
+	for _, f := range p.Findings {
+		fmt.Fprintf(&buf, "%-9s %s\n", f.Type, f)
+	}
+	fmt.Fprintf(&buf, "%d to renumber, %d findings\n", len(p.Renumber), len(p.Findings))
+	return buf.WriteTo(w)
+}
+
+// Priority returns the spec priority of a policy, zero if it has no spec resources
+func Priority(policy *schema.RoutingPolicyIntent) int16 {
+	if policy.Spec == nil || policy.Spec.Resources == nil {
+		return 0
+	}
+	return policy.Spec.Resources.Priority
+}
+
+// Sort returns the policies in evaluation order, highest priority first
+func Sort(policies []*schema.RoutingPolicyIntent) []*schema.RoutingPolicyIntent {
+	sorted := make([]*schema.RoutingPolicyIntent, len(policies))
+	copy(sorted, policies)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return Priority(sorted[i]) > Priority(sorted[j])
+	})
+	return sorted
+}
+
+// Allocate returns a free priority for a new policy at position. If there is no free priority
+// at the position, existing policies are renumbered, shifting as few policies as possible.
+func Allocate(policies []*schema.RoutingPolicyIntent, position *Position) (int16, []*Renumbering, error) {
+	sorted := Sort(policies)
+	if position == nil {
+		position = &Position{}
+	}
+
+	var index int
+	switch {
+	case position.Before != "" || position.After != "":
+		uuid := position.Before
+		if uuid == "" {
+			uuid = position.After
+		}
+		index = -1
+		for i, policy := range sorted {
+			if policy.Metadata != nil && policy.Metadata.UUID == uuid {
+				index = i
+			}
+		}
+		if index < 0 {
+			return 0, nil, fmt.Errorf("routing policy %s not found", uuid)
+		}
+		if position.After != "" {
+			index++
+		}
+	case position.Priority != 0:
+		if position.Priority < schema.RoutingPolicyPriorityMin || position.Priority > schema.RoutingPolicyPriorityMax {
+			return 0, nil, fmt.Errorf("priority %d is out of range %d-%d", position.Priority, schema.RoutingPolicyPriorityMin, schema.RoutingPolicyPriorityMax)
+		}
+		for _, policy := range sorted {
+			if Priority(policy) == position.Priority {
+				return 0, nil, fmt.Errorf("priority %d is used by %s", position.Priority, describe(policy))
+			}
+		}
+		return position.Priority, nil, nil
+	default:
+		index = len(sorted)
+	}
+
+	// the new policy goes between the priorities hi and lo
+	hi := int16(schema.RoutingPolicyPriorityMax + 1)
+	if index > 0 {
+		hi = Priority(sorted[index-1])
+	}
+	lo := int16(schema.RoutingPolicyPriorityMin - 1)
+	if index < len(sorted) {
+		lo = Priority(sorted[index])
+	}
+	if hi-lo > 1 {
+		return lo + (hi-lo)/2, nil, nil
+	}
+
+	down, downChanges, downErr := shiftDown(sorted, index, hi)
+	up, upChanges, upErr := shiftUp(sorted, index, lo)
+	switch {
+	case downErr != nil && upErr != nil:
+		return 0, nil, fmt.Errorf("no free priority at the position: %v", downErr)
+	case downErr != nil:
+		return up, upChanges, nil
+	case upErr != nil || len(downChanges) <= len(upChanges):
+		return down, downChanges, nil
+	default:
+		return up, upChanges, nil
+	}
+}
+
+// shiftDown places the new policy right below hi and decrements the priorities of the
+// following policies until there is a gap
+func shiftDown(sorted []*schema.RoutingPolicyIntent, index int, hi int16) (int16, []*Renumbering, error) {
+	priority := hi - 1
+	if priority < schema.RoutingPolicyPriorityMin {
+		return 0, nil, fmt.Errorf("no priority below %d", hi)
+	}
+	var changes []*Renumbering
+	last := priority
+	for _, policy := range sorted[index:] {
+		required := last - 1
+		if Priority(policy) <= required {
+			break
+		}
+		if required < schema.RoutingPolicyPriorityMin {
+			return 0, nil, fmt.Errorf("not enough priorities below %d", hi)
+		}
+		changes = append(changes, &Renumbering{Policy: policy, From: Priority(policy), To: required})
+		last = required
+	}
+	return priority, changes, nil
+}
+
+// shiftUp places the new policy right above lo and increments the priorities of the
+// preceding policies until there is a gap
+func shiftUp(sorted []*schema.RoutingPolicyIntent, index int, lo int16) (int16, []*Renumbering, error) {
+	priority := lo + 1
+	if priority > schema.RoutingPolicyPriorityMax {
+		return 0, nil, fmt.Errorf("no priority above %d", lo)
+	}
+	var changes []*Renumbering
+	last := priority
+	for i := index - 1; i >= 0; i-- {
+		required := last + 1
+		if Priority(sorted[i]) >= required {
+			break
+		}
+		if required > schema.RoutingPolicyPriorityMax {
+			return 0, nil, fmt.Errorf("not enough priorities above %d", lo)
+		}
+		changes = append(changes, &Renumbering{Policy: sorted[i], From: Priority(sorted[i]), To: required})
+		last = required
+	}
+	return priority, changes, nil
+}
+
+// Analyze compares every pair of policies and returns the shadowed and conflicting policies.
+// Source and destination addresses, protocol and ports are compared, bidirectional policies
+// in both directions.
+func Analyze(policies []*schema.RoutingPolicyIntent) []*Finding {
+	sorted := Sort(policies)
+	var findings []*Finding
+	for j, later := range sorted {
+		for _, earlier := range sorted[:j] {
+			if finding := compare(earlier, later); finding != nil {
+				findings = append(findings, finding)
+			}
+		}
+	}
+	return findings
+}
+
+// rule is one direction of a policy
+type rule struct {
+	source, destination *schema.NetworkAddress
+	protocolType        string
+	parameters          *schema.ProtocolParameters
+	reversed            bool
+}
+
+func rules(resources *schema.RoutingPolicyResources) []*rule {
+	forward := &rule{
+		source:       resources.Source,
+		destination:  resources.Destination,
+		protocolType: resources.ProtocolType,
+		parameters:   resources.ProtocolParameters,
+	}
+	if !resources.IsBidirectional {
+		return []*rule{forward}
+	}
+	reverse := *forward
+	reverse.source, reverse.destination = forward.destination, forward.source
+	reverse.reversed = true
+	return []*rule{forward, &reverse}
+}
+
+// compare returns a finding if later is shadowed by or conflicts with earlier
+func compare(earlier, later *schema.RoutingPolicyIntent) *Finding {
+	if earlier.Spec == nil || earlier.Spec.Resources == nil || later.Spec == nil || later.Spec.Resources == nil {
+		return nil
+	}
+	earlierRules := rules(earlier.Spec.Resources)
+	laterRules := rules(later.Spec.Resources)
+
+	shadowed := true
+	overlaps := false
+	for _, l := range laterRules {
+		covered := false
+		for _, e := range earlierRules {
+			contains, overlap := ruleContains(e, l)
+			covered = covered || contains
+			overlaps = overlaps || overlap
+		}
+		shadowed = shadowed && covered
+	}
+
+	switch {
+	case shadowed:
+		return &Finding{Type: Shadowed, Policy: later, By: earlier}
+	case overlaps && !sameAction(earlier.Spec.Resources.Action, later.Spec.Resources.Action):
+		return &Finding{Type: Conflict, Policy: later, By: earlier}
+	}
+	return nil
+}
+
+// ruleContains reports whether a matches all traffic of b and whether they match common traffic
+func ruleContains(a, b *rule) (bool, bool) {
+	sourceContains, sourceOverlaps := addressContains(a.source, b.source)
+	destinationContains, destinationOverlaps := addressContains(a.destination, b.destination)
+	protocolContains, protocolOverlaps := protocolContains(a, b)
+	return sourceContains && destinationContains && protocolContains,
+		sourceOverlaps && destinationOverlaps && protocolOverlaps
+}
+
+// addressContains reports whether a contains b and whether they overlap. INTERNET is treated
+// as every address outside of the private ranges.
+func addressContains(a, b *schema.NetworkAddress) (bool, bool) {
+	aAll, bAll := isAll(a), isAll(b)
+	aInternet, bInternet := a != nil && a.AddressType == schema.AddressTypeInternet, b != nil && b.AddressType == schema.AddressTypeInternet
+	switch {
+	case aAll:
+		return true, true
+	case bAll:
+		return false, true
+	case aInternet && bInternet:
+		return true, true
+	}
+
+	if aInternet || bInternet {
+		subnet := a
+		if aInternet {
+			subnet = b
+		}
+		prefix := ipPrefix(subnet)
+		if prefix == nil {
+			return false, false
+		}
+		for _, private := range privatePrefixes {
+			if contains(private, prefix) {
+				return false, false
+			}
+		}
+		if aInternet {
+			for _, private := range privatePrefixes {
+				if overlaps(private, prefix) {
+					return false, true
+				}
+			}
+			return true, true
+		}
+		return false, true
+	}
+
+	aPrefix, bPrefix := ipPrefix(a), ipPrefix(b)
+	if aPrefix == nil || bPrefix == nil {
+		return false, false
+	}
+	return contains(aPrefix, bPrefix), overlaps(aPrefix, bPrefix)
+}
+
+func isAll(address *schema.NetworkAddress) bool {
+	return address == nil || address.AddressType == schema.AddressTypeAll || (address.AddressType == "" && address.IPSubnet == nil)
+}
+
+// ipPrefix returns the ipv4 or ipv6 prefix of the address, nil if it has no valid prefix.
+// Prefixes of different families neither contain nor overlap each other.
+func ipPrefix(address *schema.NetworkAddress) *net.IPNet {
+	if address.IPSubnet == nil {
+		return nil
+	}
+	ip := net.ParseIP(address.IPSubnet.IP)
+	bits := 8 * net.IPv6len
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, bits = ip4, 8*net.IPv4len
+	}
+	if ip == nil || address.IPSubnet.PrefixLength < 0 || address.IPSubnet.PrefixLength > int64(bits) {
+		return nil
+	}
+	mask := net.CIDRMask(int(address.IPSubnet.PrefixLength), bits)
+	return &net.IPNet{IP: ip.Mask(mask), Mask: mask}
+}
+
+func contains(a, b *net.IPNet) bool {
+	aOnes, _ := a.Mask.Size()
+	bOnes, _ := b.Mask.Size()
+	return aOnes <= bOnes && a.Contains(b.IP)
+}
+
+func overlaps(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// protocolContains reports whether the protocol and ports of a contain those of b and whether they overlap
+func protocolContains(a, b *rule) (bool, bool) {
+	switch {
+	case a.protocolType == "" || a.protocolType == schema.ProtocolTypeAll:
+		return true, true
+	case b.protocolType == "" || b.protocolType == schema.ProtocolTypeAll:
+		return false, true
+	case a.protocolType != b.protocolType:
+		return false, false
+	}
+
+	switch a.protocolType {
+	case schema.ProtocolTypeTCP, schema.ProtocolTypeUDP:
+		aSource, aDestination := ports(a)
+		bSource, bDestination := ports(b)
+		return rangesContain(aSource, bSource) && rangesContain(aDestination, bDestination),
+			rangesOverlap(aSource, bSource) && rangesOverlap(aDestination, bDestination)
+	case schema.ProtocolTypeICMP:
+		var aIcmp, bIcmp *schema.Icmp
+		if a.parameters != nil {
+			aIcmp = a.parameters.Icmp
+		}
+		if b.parameters != nil {
+			bIcmp = b.parameters.Icmp
+		}
+		if aIcmp == nil {
+			aIcmp = &schema.Icmp{}
+		}
+		if bIcmp == nil {
+			bIcmp = &schema.Icmp{}
+		}
+		typeContains, typeOverlaps := uint8Contains(aIcmp.IcmpType, bIcmp.IcmpType)
+		codeContains, codeOverlaps := uint8Contains(aIcmp.IcmpCode, bIcmp.IcmpCode)
+		return typeContains && codeContains, typeOverlaps && codeOverlaps
+	case schema.ProtocolTypeProtocolNumber:
+		var aNumber, bNumber *uint8
+		if a.parameters != nil {
+			aNumber = a.parameters.ProtocolNumber
+		}
+		if b.parameters != nil {
+			bNumber = b.parameters.ProtocolNumber
+		}
+		return uint8Contains(aNumber, bNumber)
+	}
+	return false, false
+}
+
+// uint8Contains compares optional values, nil matches every value
+func uint8Contains(a, b *uint8) (bool, bool) {
+	switch {
+	case a == nil:
+		return true, true
+	case b == nil:
+		return false, true
+	}
+	return *a == *b, *a == *b
+}
+
+// ports returns the source and destination port ranges of a tcp or udp rule. Empty lists
+// match every port.
+func ports(r *rule) ([]*schema.PortRange, []*schema.PortRange) {
+	var source, destination []*schema.PortRange
+	if r.parameters != nil {
+		switch {
+		case r.protocolType == schema.ProtocolTypeTCP && r.parameters.TCP != nil:
+			tcp := r.parameters.TCP
+			source = withDeprecated(tcp.SourcePortRangeList, tcp.SourcePortRange)
+			destination = withDeprecated(tcp.DestinationPortRangeList, tcp.DestinationPortRange)
+		case r.protocolType == schema.ProtocolTypeUDP && r.parameters.UDP != nil:
+			udp := r.parameters.UDP
+			source = withDeprecated(udp.SourcePortRangeList, udp.SourcePortRange)
+			destination = withDeprecated(udp.DestinationPortRangeList, udp.DestinationPortRange)
+		}
+	}
+	if r.reversed {
+		return destination, source
+	}
+	return source, destination
+}
+
+func withDeprecated(list []*schema.PortRange, single *schema.PortRange) []*schema.PortRange {
+	if len(list) == 0 && single != nil {
+		return []*schema.PortRange{single}
+	}
+	return list
+}
+
+func bounds(r *schema.PortRange) (int64, int64) {
+	end := r.EndPort
+	if end < r.StartPort {
+		end = r.StartPort
+	}
+	return r.StartPort, end
+}
+
+// rangesContain reports whether every range of b is within a range of a
+func rangesContain(a, b []*schema.PortRange) bool {
+	if len(a) == 0 {
+		return true
+	}
+	if len(b) == 0 {
+		b = []*schema.PortRange{{StartPort: minPort, EndPort: maxPort}}
+	}
+	for _, br := range b {
+		bStart, bEnd := bounds(br)
+		covered := false
+		for _, ar := range a {
+			aStart, aEnd := bounds(ar)
+			if aStart <= bStart && bEnd <= aEnd {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+func rangesOverlap(a, b []*schema.PortRange) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	for _, ar := range a {
+		aStart, aEnd := bounds(ar)
+		for _, br := range b {
+			bStart, bEnd := bounds(br)
+			if aStart <= bEnd && bStart <= aEnd {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sameAction(a, b *schema.RoutingPolicyAction) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Action != b.Action || len(a.ServiceIPList) != len(b.ServiceIPList) {
+		return false
+	}
+	for i := range a.ServiceIPList {
+		if a.ServiceIPList[i] != b.ServiceIPList[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func describe(policy *schema.RoutingPolicyIntent) string {
+	var name, uuid string
+	if policy.Spec != nil {
+		name = policy.Spec.Name
+	}
+	if policy.Metadata != nil {
+		uuid = policy.Metadata.UUID
+	}
+	if uuid == "" {
+		return fmt.Sprintf("routing policy %q", name)
+	}
+	return fmt.Sprintf("routing policy %q (%s)", name, uuid)
+}
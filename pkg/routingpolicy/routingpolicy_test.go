@@ -0,0 +1,416 @@
+package routingpolicy
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+func policy(uuid string, priority int16) *schema.RoutingPolicyIntent {
+	return &schema.RoutingPolicyIntent{
+		Metadata: &schema.Metadata{UUID: uuid},
+		Spec: &schema.RoutingPolicy{
+			Name: uuid,
+			Resources: &schema.RoutingPolicyResources{
+				Priority: priority,
+				Action:   &schema.RoutingPolicyAction{Action: schema.RoutingPolicyActionPermit},
+			},
+		},
+	}
+}
+
+// priorities returns policies named p<priority> for each priority
+func priorities(values ...int16) []*schema.RoutingPolicyIntent {
+	policies := make([]*schema.RoutingPolicyIntent, 0, len(values))
+	for _, value := range values {
+		policies = append(policies, policy(fmt.Sprintf("p%d", value), value))
+	}
+	return policies
+}
+
+// priorityRange returns policies with every priority from lo to hi
+func priorityRange(lo, hi int16) []*schema.RoutingPolicyIntent {
+	var values []int16
+	for p := lo; p <= hi; p++ {
+		values = append(values, p)
+	}
+	return priorities(values...)
+}
+
+func renumbered(changes []*Renumbering) []string {
+	result := make([]string, 0, len(changes))
+	for _, r := range changes {
+		result = append(result, fmt.Sprintf("%s:%d->%d", r.Policy.Metadata.UUID, r.From, r.To))
+	}
+	return result
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies []*schema.RoutingPolicyIntent
+		position *Position
+		priority int16
+		renumber []string
+		err      string
+	}{
+		{
+			name:     "empty vpc",
+			priority: 500,
+			renumber: []string{},
+		},
+		{
+			name:     "after all others",
+			policies: priorities(900, 500),
+			priority: 250,
+			renumber: []string{},
+		},
+		{
+			name:     "before a policy",
+			policies: priorities(900, 500),
+			position: &Position{Before: "p500"},
+			priority: 700,
+			renumber: []string{},
+		},
+		{
+			name:     "after a policy",
+			policies: priorities(500, 900),
+			position: &Position{After: "p900"},
+			priority: 700,
+			renumber: []string{},
+		},
+		{
+			name:     "before the first policy",
+			policies: priorities(900),
+			position: &Position{Before: "p900"},
+			priority: 950,
+			renumber: []string{},
+		},
+		{
+			name:     "unknown policy",
+			policies: priorities(900),
+			position: &Position{After: "missing"},
+			err:      "routing policy missing not found",
+		},
+		{
+			name:     "free priority",
+			policies: priorities(900),
+			position: &Position{Priority: 300},
+			priority: 300,
+			renumber: []string{},
+		},
+		{
+			name:     "used priority",
+			policies: priorities(900),
+			position: &Position{Priority: 900},
+			err:      "priority 900 is used",
+		},
+		{
+			name:     "priority above the range",
+			position: &Position{Priority: schema.RoutingPolicyPriorityMax + 1},
+			err:      "out of range",
+		},
+		{
+			name:     "priority below the range",
+			position: &Position{Priority: -1},
+			err:      "out of range",
+		},
+		{
+			name:     "no gap prefers shifting down on a tie",
+			policies: priorities(501, 500),
+			position: &Position{Before: "p500"},
+			priority: 500,
+			renumber: []string{"p500:500->499"},
+		},
+		{
+			name:     "no gap shifts the side with fewer policies",
+			policies: priorities(600, 501, 500, 499, 498),
+			position: &Position{Before: "p500"},
+			priority: 501,
+			renumber: []string{"p501:501->502"},
+		},
+		{
+			name:     "shift down renumbers in evaluation order until a gap",
+			policies: priorities(1000, 999, 998, 997, 500),
+			position: &Position{After: "p1000"},
+			priority: 999,
+			renumber: []string{"p999:999->998", "p998:998->997", "p997:997->996"},
+		},
+		{
+			name:     "lowest priority used shifts up",
+			policies: priorityRange(1, 3),
+			priority: 1,
+			renumber: []string{"p1:1->2", "p2:2->3", "p3:3->4"},
+		},
+		{
+			name:     "highest priority used shifts down",
+			policies: priorityRange(998, 1000),
+			position: &Position{Before: "p1000"},
+			priority: 1000,
+			renumber: []string{"p1000:1000->999", "p999:999->998", "p998:998->997"},
+		},
+		{
+			name:     "full range",
+			policies: priorityRange(schema.RoutingPolicyPriorityMin, schema.RoutingPolicyPriorityMax),
+			err:      "no free priority",
+		},
+		{
+			name:     "full range before a policy",
+			policies: priorityRange(schema.RoutingPolicyPriorityMin, schema.RoutingPolicyPriorityMax),
+			position: &Position{Before: "p500"},
+			err:      "no free priority",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			priority, changes, err := Allocate(tt.policies, tt.position)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("err = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if priority != tt.priority {
+				t.Errorf("priority = %d, want %d", priority, tt.priority)
+			}
+			if got := renumbered(changes); !reflect.DeepEqual(got, tt.renumber) {
+				t.Errorf("renumber = %v, want %v", got, tt.renumber)
+			}
+		})
+	}
+}
+
+func TestAllocateLeavesOneFreePriority(t *testing.T) {
+	policies := priorityRange(schema.RoutingPolicyPriorityMin+1, schema.RoutingPolicyPriorityMax)
+	priority, changes, err := Allocate(policies, &Position{Before: "p2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if priority != 2 || len(changes) != 1 || changes[0].To != 1 {
+		t.Errorf("got priority %d and %v, want 2 and p2 renumbered to 1", priority, renumbered(changes))
+	}
+
+	priority, changes, err = Allocate(policies, &Position{Before: "p1000"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if priority != 1000 || len(changes) != len(policies) {
+		t.Errorf("got priority %d and %d changes, want 1000 and %d", priority, len(changes), len(policies))
+	}
+}
+
+func address(value string) *schema.NetworkAddress {
+	switch value {
+	case "":
+		return &schema.NetworkAddress{AddressType: schema.AddressTypeAll}
+	case schema.AddressTypeInternet:
+		return &schema.NetworkAddress{AddressType: schema.AddressTypeInternet}
+	}
+	ip, prefix, err := net.ParseCIDR(value)
+	if err != nil {
+		panic(err)
+	}
+	ones, _ := prefix.Mask.Size()
+	return &schema.NetworkAddress{IPSubnet: &schema.IPSubnet{IP: ip.String(), PrefixLength: int64(ones)}}
+}
+
+type rulePolicy struct {
+	source, destination string
+	action              string
+	bidirectional       bool
+	tcpPorts            []int64
+}
+
+func (r rulePolicy) build(uuid string, priority int16) *schema.RoutingPolicyIntent {
+	p := policy(uuid, priority)
+	resources := p.Spec.Resources
+	resources.Source = address(r.source)
+	resources.Destination = address(r.destination)
+	resources.IsBidirectional = r.bidirectional
+	resources.ProtocolType = schema.ProtocolTypeAll
+	if r.action != "" {
+		resources.Action.Action = r.action
+	}
+	if r.tcpPorts != nil {
+		resources.ProtocolType = schema.ProtocolTypeTCP
+		resources.ProtocolParameters = &schema.ProtocolParameters{TCP: &schema.TCP{
+			DestinationPortRangeList: []*schema.PortRange{{StartPort: r.tcpPorts[0], EndPort: r.tcpPorts[1]}},
+		}}
+	}
+	return p
+}
+
+func TestAnalyze(t *testing.T) {
+	deny := schema.RoutingPolicyActionDeny
+	tests := []struct {
+		name           string
+		earlier, later rulePolicy
+		want           FindingType
+	}{
+		{
+			name:    "contained source is shadowed",
+			earlier: rulePolicy{source: "10.0.0.0/8"},
+			later:   rulePolicy{source: "10.1.0.0/16", action: deny},
+			want:    Shadowed,
+		},
+		{
+			name:    "equal prefixes are shadowed",
+			earlier: rulePolicy{source: "10.1.0.0/16", destination: "192.168.1.0/24"},
+			later:   rulePolicy{source: "10.1.0.0/16", destination: "192.168.1.0/24"},
+			want:    Shadowed,
+		},
+		{
+			name:    "host bits are ignored",
+			earlier: rulePolicy{source: "10.1.2.3/16"},
+			later:   rulePolicy{source: "10.1.200.0/24"},
+			want:    Shadowed,
+		},
+		{
+			name:    "containing prefix with another action conflicts",
+			earlier: rulePolicy{source: "10.1.0.0/16"},
+			later:   rulePolicy{source: "10.0.0.0/8", action: deny},
+			want:    Conflict,
+		},
+		{
+			name:    "containing prefix with the same action",
+			earlier: rulePolicy{source: "10.1.0.0/16"},
+			later:   rulePolicy{source: "10.0.0.0/8"},
+		},
+		{
+			name:    "overlapping source, disjoint destination",
+			earlier: rulePolicy{source: "10.0.0.0/8", destination: "192.168.1.0/24"},
+			later:   rulePolicy{source: "10.0.0.0/8", destination: "192.168.2.0/24", action: deny},
+		},
+		{
+			name:    "disjoint prefixes",
+			earlier: rulePolicy{source: "10.0.0.0/8"},
+			later:   rulePolicy{source: "172.16.0.0/12", action: deny},
+		},
+		{
+			name:    "adjacent prefixes",
+			earlier: rulePolicy{source: "10.0.0.0/24"},
+			later:   rulePolicy{source: "10.0.1.0/24", action: deny},
+		},
+		{
+			name:    "all shadows a prefix",
+			earlier: rulePolicy{},
+			later:   rulePolicy{destination: "8.8.8.0/24", action: deny},
+			want:    Shadowed,
+		},
+		{
+			name:    "prefix conflicts with all",
+			earlier: rulePolicy{destination: "8.8.8.0/24"},
+			later:   rulePolicy{action: deny},
+			want:    Conflict,
+		},
+		{
+			name:    "internet shadows a public prefix",
+			earlier: rulePolicy{destination: schema.AddressTypeInternet},
+			later:   rulePolicy{destination: "8.8.8.0/24", action: deny},
+			want:    Shadowed,
+		},
+		{
+			name:    "internet does not match a private prefix",
+			earlier: rulePolicy{destination: schema.AddressTypeInternet},
+			later:   rulePolicy{destination: "192.168.0.0/24", action: deny},
+		},
+		{
+			name:    "internet overlaps a prefix containing private ranges",
+			earlier: rulePolicy{destination: schema.AddressTypeInternet},
+			later:   rulePolicy{destination: "0.0.0.0/0", action: deny},
+			want:    Conflict,
+		},
+		{
+			name:    "contained ipv6 prefix is shadowed",
+			earlier: rulePolicy{source: "2001:db8::/32"},
+			later:   rulePolicy{source: "2001:db8:1::/48", action: deny},
+			want:    Shadowed,
+		},
+		{
+			name:    "containing ipv6 prefix with another action conflicts",
+			earlier: rulePolicy{destination: "2001:db8:1::/48"},
+			later:   rulePolicy{destination: "2001:db8::/32", action: deny},
+			want:    Conflict,
+		},
+		{
+			name:    "disjoint ipv6 prefixes",
+			earlier: rulePolicy{source: "2001:db8:1::/48"},
+			later:   rulePolicy{source: "2001:db8:2::/48", action: deny},
+		},
+		{
+			name:    "ipv4 and ipv6 prefixes do not overlap",
+			earlier: rulePolicy{source: "0.0.0.0/0"},
+			later:   rulePolicy{source: "::/0", action: deny},
+		},
+		{
+			name:    "ipv6 all does not contain ipv4 prefixes",
+			earlier: rulePolicy{destination: "::/0"},
+			later:   rulePolicy{destination: "10.0.0.0/8", action: deny},
+		},
+		{
+			name:    "internet shadows a public ipv6 prefix",
+			earlier: rulePolicy{destination: schema.AddressTypeInternet},
+			later:   rulePolicy{destination: "2001:db8::/32", action: deny},
+			want:    Shadowed,
+		},
+		{
+			name:    "internet does not match a unique local ipv6 prefix",
+			earlier: rulePolicy{destination: schema.AddressTypeInternet},
+			later:   rulePolicy{destination: "fd00:1::/64", action: deny},
+		},
+		{
+			name:    "bidirectional later is not shadowed by one direction",
+			earlier: rulePolicy{source: "10.0.0.0/8", destination: "192.168.0.0/16"},
+			later:   rulePolicy{source: "10.1.0.0/16", destination: "192.168.1.0/24", bidirectional: true, action: deny},
+			want:    Conflict,
+		},
+		{
+			name:    "bidirectional earlier shadows the reverse direction",
+			earlier: rulePolicy{source: "10.0.0.0/8", destination: "192.168.0.0/16", bidirectional: true},
+			later:   rulePolicy{source: "192.168.1.0/24", destination: "10.1.0.0/16"},
+			want:    Shadowed,
+		},
+		{
+			name:    "contained port range is shadowed",
+			earlier: rulePolicy{source: "10.0.0.0/8", tcpPorts: []int64{80, 443}},
+			later:   rulePolicy{source: "10.1.0.0/16", tcpPorts: []int64{443, 443}, action: deny},
+			want:    Shadowed,
+		},
+		{
+			name:    "disjoint port ranges",
+			earlier: rulePolicy{source: "10.0.0.0/8", tcpPorts: []int64{80, 80}},
+			later:   rulePolicy{source: "10.1.0.0/16", tcpPorts: []int64{443, 443}, action: deny},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			earlier := tt.earlier.build("earlier", 200)
+			later := tt.later.build("later", 100)
+
+			// the input order must not matter, policies are compared in evaluation order
+			findings := Analyze([]*schema.RoutingPolicyIntent{later, earlier})
+			if tt.want == "" {
+				if len(findings) != 0 {
+					t.Fatalf("findings = %v, want none", findings)
+				}
+				return
+			}
+			if len(findings) != 1 {
+				t.Fatalf("findings = %v, want one %s", findings, tt.want)
+			}
+			finding := findings[0]
+			if finding.Type != tt.want || finding.Policy != later || finding.By != earlier {
+				t.Errorf("finding = %s %s, want %s of later by earlier", finding.Type, finding, tt.want)
+			}
+		})
+	}
+}
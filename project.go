@@ -70,12 +70,37 @@ func (c *ProjectClient) Create(ctx context.Context, createRequest *schema.Projec
 
 // Update a project
 func (c *ProjectClient) Update(ctx context.Context, project *schema.ProjectIntent) (*schema.ProjectIntent, error) {
-	project.Status = nil
 	response := new(schema.ProjectIntent)
-	err := c.client.requestHelper(ctx, fmt.Sprintf(projectSinglePath, project.Metadata.UUID), http.MethodPut, project, response)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(projectSinglePath, project.Metadata.UUID), http.MethodPut, project.ToUpdateRequest(), response)
 	return response, err
 }
 
+// UpdateWith fetches the latest project, applies mutate to it and updates it. On a
+// spec_version conflict the project is fetched again and mutate is re-applied.
+// It returns the updated project and the uuid of the update task.
+func (c *ProjectClient) UpdateWith(ctx context.Context, uuid string, mutate func(*schema.ProjectIntent) error) (*schema.ProjectIntent, string, error) {
+	var response *schema.ProjectIntent
+	err := c.client.retryOnConflict(ctx, func() error {
+		project, err := c.GetByUUID(ctx, uuid)
+		if err != nil {
+			return err
+		}
+		if err = mutate(project); err != nil {
+			return err
+		}
+		response, err = c.Update(ctx, project)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	var taskUUID string
+	if response.Status != nil {
+		taskUUID = response.Status.ExecutionContext.GetTaskUUID()
+	}
+	return response, taskUUID, nil
+}
+
 // Delete deletes a project
 func (c *ProjectClient) Delete(ctx context.Context, uuid string) error {
 	return c.client.requestHelper(ctx, fmt.Sprintf(projectSinglePath, uuid), http.MethodDelete, nil, nil)
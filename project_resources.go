@@ -0,0 +1,235 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+const bytesPerMib = 1024 * 1024
+
+// ProjectResourceUsage is the utilization of a project resource type
+type ProjectResourceUsage struct {
+	ResourceType string
+	Units        string
+	Used         int64
+
+	// Limit is zero if the resource is unlimited
+	Limit int64
+}
+
+// Remaining returns the unused part of the limit, -1 if the resource is unlimited
+func (u *ProjectResourceUsage) Remaining() int64 {
+	if u.Limit <= 0 {
+		return -1
+	}
+	if u.Used > u.Limit {
+		return 0
+	}
+	return u.Limit - u.Used
+}
+
+// QuotaError is returned if a vm does not fit in the remaining quota of a project
+type QuotaError struct {
+	ProjectUUID  string
+	ResourceType string
+	Used         int64
+	Required     int64
+	Limit        int64
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("project %s %s quota exceeded: %d used, %d required, limit %d", e.ProjectUUID, e.ResourceType, e.Used, e.Required, e.Limit)
+}
+
+// AddUser adds the user to the project if it is not a member yet
+func (c *ProjectClient) AddUser(ctx context.Context, uuid string, user *schema.Reference) (*schema.ProjectIntent, error) {
+	return c.updateAndWait(ctx, uuid, func(resources *schema.ProjectResources) {
+		resources.UserReferenceList = addReference(resources.UserReferenceList, user)
+	})
+}
+
+// RemoveUser removes the user from the project
+func (c *ProjectClient) RemoveUser(ctx context.Context, uuid, userUUID string) (*schema.ProjectIntent, error) {
+	return c.updateAndWait(ctx, uuid, func(resources *schema.ProjectResources) {
+		resources.UserReferenceList = removeReference(resources.UserReferenceList, userUUID)
+	})
+}
+
+// AddUserGroup adds the directory service user group to the project if it is not a member yet
+func (c *ProjectClient) AddUserGroup(ctx context.Context, uuid string, userGroup *schema.Reference) (*schema.ProjectIntent, error) {
+	return c.updateAndWait(ctx, uuid, func(resources *schema.ProjectResources) {
+		resources.ExternalUserGroupReferenceList = addReference(resources.ExternalUserGroupReferenceList, userGroup)
+	})
+}
+
+// RemoveUserGroup removes the directory service user group from the project
+func (c *ProjectClient) RemoveUserGroup(ctx context.Context, uuid, userGroupUUID string) (*schema.ProjectIntent, error) {
+	return c.updateAndWait(ctx, uuid, func(resources *schema.ProjectResources) {
+		resources.ExternalUserGroupReferenceList = removeReference(resources.ExternalUserGroupReferenceList, userGroupUUID)
+	})
+}
+
+// AddSubnet adds the subnet to the project if it is not part of it yet
+func (c *ProjectClient) AddSubnet(ctx context.Context, uuid string, subnet *schema.Reference) (*schema.ProjectIntent, error) {
+	return c.updateAndWait(ctx, uuid, func(resources *schema.ProjectResources) {
+		resources.SubnetReferenceList = addReference(resources.SubnetReferenceList, subnet)
+	})
+}
+
+// RemoveSubnet removes the subnet from the project. It is also cleared as default subnet.
+func (c *ProjectClient) RemoveSubnet(ctx context.Context, uuid, subnetUUID string) (*schema.ProjectIntent, error) {
+	return c.updateAndWait(ctx, uuid, func(resources *schema.ProjectResources) {
+		resources.SubnetReferenceList = removeReference(resources.SubnetReferenceList, subnetUUID)
+		if resources.DefaultSubnetReference != nil && resources.DefaultSubnetReference.UUID == subnetUUID {
+			resources.DefaultSubnetReference = nil
+		}
+	})
+}
+
+// SetQuota sets the vcpu, memory and storage limits of the project. Memory and storage are in
+// bytes. A zero limit removes the quota of that resource type. Quotas of other resource types
+// are kept.
+func (c *ProjectClient) SetQuota(ctx context.Context, uuid string, vcpus, memoryBytes, storageBytes int64) (*schema.ProjectIntent, error) {
+	limits := map[string]int64{
+		schema.ResourceTypeVCPUs:   vcpus,
+		schema.ResourceTypeMemory:  memoryBytes,
+		schema.ResourceTypeStorage: storageBytes,
+	}
+	return c.updateAndWait(ctx, uuid, func(resources *schema.ProjectResources) {
+		domain := &schema.ResourceDomainSpec{}
+		if resources.ResourceDomain != nil {
+			for _, resource := range resources.ResourceDomain.Resources {
+				limit, ok := limits[resource.ResourceType]
+				if !ok {
+					domain.Resources = append(domain.Resources, resource)
+					continue
+				}
+				delete(limits, resource.ResourceType)
+				if limit > 0 {
+					resource.Limit = limit
+					domain.Resources = append(domain.Resources, resource)
+				}
+			}
+		}
+		for _, resourceType := range []string{schema.ResourceTypeVCPUs, schema.ResourceTypeMemory, schema.ResourceTypeStorage} {
+			if limit, ok := limits[resourceType]; ok && limit > 0 {
+				domain.Resources = append(domain.Resources, &schema.ResourceUtilizationSpec{ResourceType: resourceType, Limit: limit})
+			}
+		}
+		resources.ResourceDomain = nil
+		if len(domain.Resources) > 0 {
+			resources.ResourceDomain = domain
+		}
+	})
+}
+
+// Utilization returns the resource usage of the project by resource type
+func (c *ProjectClient) Utilization(ctx context.Context, uuid string) (map[string]*ProjectResourceUsage, error) {
+	project, err := c.GetByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make(map[string]*ProjectResourceUsage)
+	if project.Status == nil || project.Status.Resources == nil || project.Status.Resources.ResourceDomain == nil {
+		return usage, nil
+	}
+	for _, resource := range project.Status.Resources.ResourceDomain.Resources {
+		usage[resource.ResourceType] = &ProjectResourceUsage{
+			ResourceType: resource.ResourceType,
+			Units:        resource.Units,
+			Used:         resource.Value,
+			Limit:        resource.Limit,
+		}
+	}
+	return usage, nil
+}
+
+// CheckQuota verifies the vm fits in the remaining quota of the project. A *QuotaError is
+// returned for the first exceeded resource type.
+func (c *ProjectClient) CheckQuota(ctx context.Context, uuid string, vm *schema.VMIntent) error {
+	if vm.Spec == nil || vm.Spec.Resources == nil {
+		return fmt.Errorf("vm has no spec resources")
+	}
+	usage, err := c.Utilization(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	required := map[string]int64{
+		schema.ResourceTypeVCPUs:   VMVCPUs(vm.Spec.Resources),
+		schema.ResourceTypeMemory:  vm.Spec.Resources.MemorySizeMib * bytesPerMib,
+		schema.ResourceTypeStorage: vmStorageBytes(vm.Spec.Resources),
+	}
+	for _, resourceType := range []string{schema.ResourceTypeVCPUs, schema.ResourceTypeMemory, schema.ResourceTypeStorage} {
+		u, ok := usage[resourceType]
+		if !ok || u.Limit <= 0 {
+			continue
+		}
+		if u.Used+required[resourceType] > u.Limit {
+			return &QuotaError{
+				ProjectUUID:  uuid,
+				ResourceType: resourceType,
+				Used:         u.Used,
+				Required:     required[resourceType],
+				Limit:        u.Limit,
+			}
+		}
+	}
+	return nil
+}
+
+// updateAndWait applies mutate to the spec resources of the project with UpdateWith and waits
+// for the update task
+func (c *ProjectClient) updateAndWait(ctx context.Context, uuid string, mutate func(*schema.ProjectResources)) (*schema.ProjectIntent, error) {
+	_, taskUUID, err := c.UpdateWith(ctx, uuid, func(project *schema.ProjectIntent) error {
+		if project.Spec == nil || project.Spec.Resources == nil {
+			return fmt.Errorf("project %s has no spec resources", uuid)
+		}
+		mutate(project.Spec.Resources)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = c.client.Task.Wait(ctx, taskUUID); err != nil {
+		return nil, err
+	}
+	return c.GetByUUID(ctx, uuid)
+}
+
+// addReference appends ref to refs unless a reference with the same uuid exists
+func addReference(refs []*schema.Reference, ref *schema.Reference) []*schema.Reference {
+	for _, r := range refs {
+		if r.UUID == ref.UUID {
+			return refs
+		}
+	}
+	return append(refs, ref)
+}
+
+// removeReference returns refs without the references with the given uuid
+func removeReference(refs []*schema.Reference, uuid string) []*schema.Reference {
+	result := make([]*schema.Reference, 0, len(refs))
+	for _, r := range refs {
+		if r.UUID != uuid {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// vmStorageBytes returns the sum of the disk sizes of the vm
+func vmStorageBytes(resources *schema.VMResources) int64 {
+	var total int64
+	for _, disk := range resources.DiskList {
+		if disk.DiskSizeBytes > 0 {
+			total += disk.DiskSizeBytes
+		} else {
+			total += disk.DiskSizeMib * bytesPerMib
+		}
+	}
+	return total
+}
@@ -0,0 +1,107 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/pkg/utils"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+const (
+	protectionRuleBasePath   = "/protection_rules"
+	protectionRuleListPath   = protectionRuleBasePath + "/list"
+	protectionRuleSinglePath = protectionRuleBasePath + "/%s"
+)
+
+// ProtectionRuleClient is a client for the protection rule API.
+type ProtectionRuleClient struct {
+	client *Client
+}
+
+// Get retrieves a protection rule by its UUID if the input can be parsed as an uuid, otherwise it
+// retrieves a protection rule by its name
+func (c *ProtectionRuleClient) Get(ctx context.Context, idOrName string) (*schema.ProtectionRuleIntent, error) {
+	if utils.IsValidUUID(idOrName) {
+		return c.GetByUUID(ctx, idOrName)
+	}
+	return c.GetByName(ctx, idOrName)
+}
+
+// GetByUUID retrieves a protection rule by its UUID
+func (c *ProtectionRuleClient) GetByUUID(ctx context.Context, uuid string) (*schema.ProtectionRuleIntent, error) {
+	response := new(schema.ProtectionRuleIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(protectionRuleSinglePath, uuid), http.MethodGet, nil, response)
+	return response, err
+}
+
+// GetByName retrieves a protection rule by its name
+func (c *ProtectionRuleClient) GetByName(ctx context.Context, name string) (*schema.ProtectionRuleIntent, error) {
+	list, err := c.List(ctx, &schema.DSMetadata{Filter: fmt.Sprintf("name==%s", name)})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Entities) == 0 {
+		return nil, fmt.Errorf("protection rule not found: %s", name)
+	}
+	return list.Entities[0], err
+}
+
+// List returns a list of protection rules
+func (c *ProtectionRuleClient) List(ctx context.Context, opts *schema.DSMetadata) (*schema.ProtectionRuleListIntent, error) {
+	response := new(schema.ProtectionRuleListIntent)
+	err := c.client.requestHelper(ctx, protectionRuleListPath, http.MethodPost, opts, response)
+	return response, err
+
+}
+
+// All returns all protection rules
+func (c *ProtectionRuleClient) All(ctx context.Context) (*schema.ProtectionRuleListIntent, error) {
+	return c.List(ctx, &schema.DSMetadata{Length: utils.Int64Ptr(itemsPerPage), Offset: utils.Int64Ptr(0)})
+}
+
+// Create creates a protection rule
+func (c *ProtectionRuleClient) Create(ctx context.Context, createRequest *schema.ProtectionRuleIntent) (*schema.ProtectionRuleIntent, error) {
+	response := new(schema.ProtectionRuleIntent)
+	err := c.client.requestHelper(ctx, protectionRuleBasePath, http.MethodPost, createRequest, response)
+	return response, err
+}
+
+// Update a protection rule
+func (c *ProtectionRuleClient) Update(ctx context.Context, protectionRule *schema.ProtectionRuleIntent) (*schema.ProtectionRuleIntent, error) {
+	response := new(schema.ProtectionRuleIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(protectionRuleSinglePath, protectionRule.Metadata.UUID), http.MethodPut, protectionRule.ToUpdateRequest(), response)
+	return response, err
+}
+
+// UpdateWith fetches the latest protection rule, applies mutate to it and updates it. On a
+// spec_version conflict the protection rule is fetched again and mutate is re-applied.
+// It returns the updated protection rule and the uuid of the update task.
+func (c *ProtectionRuleClient) UpdateWith(ctx context.Context, uuid string, mutate func(*schema.ProtectionRuleIntent) error) (*schema.ProtectionRuleIntent, string, error) {
+	var response *schema.ProtectionRuleIntent
+	err := c.client.retryOnConflict(ctx, func() error {
+		protectionRule, err := c.GetByUUID(ctx, uuid)
+		if err != nil {
+			return err
+		}
+		if err = mutate(protectionRule); err != nil {
+			return err
+		}
+		response, err = c.Update(ctx, protectionRule)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	var taskUUID string
+	if response.Status != nil {
+		taskUUID = response.Status.ExecutionContext.GetTaskUUID()
+	}
+	return response, taskUUID, nil
+}
+
+// Delete deletes a protection rule
+func (c *ProtectionRuleClient) Delete(ctx context.Context, uuid string) error {
+	return c.client.requestHelper(ctx, fmt.Sprintf(protectionRuleSinglePath, uuid), http.MethodDelete, nil, nil)
+}
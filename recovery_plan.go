@@ -0,0 +1,107 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/pkg/utils"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+const (
+	recoveryPlanBasePath   = "/recovery_plans"
+	recoveryPlanListPath   = recoveryPlanBasePath + "/list"
+	recoveryPlanSinglePath = recoveryPlanBasePath + "/%s"
+)
+
+// RecoveryPlanClient is a client for the recovery plan API.
+type RecoveryPlanClient struct {
+	client *Client
+}
+
+// Get retrieves a recovery plan by its UUID if the input can be parsed as an uuid, otherwise it
+// retrieves a recovery plan by its name
+func (c *RecoveryPlanClient) Get(ctx context.Context, idOrName string) (*schema.RecoveryPlanIntent, error) {
+	if utils.IsValidUUID(idOrName) {
+		return c.GetByUUID(ctx, idOrName)
+	}
+	return c.GetByName(ctx, idOrName)
+}
+
+// GetByUUID retrieves a recovery plan by its UUID
+func (c *RecoveryPlanClient) GetByUUID(ctx context.Context, uuid string) (*schema.RecoveryPlanIntent, error) {
+	response := new(schema.RecoveryPlanIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(recoveryPlanSinglePath, uuid), http.MethodGet, nil, response)
+	return response, err
+}
+
+// GetByName retrieves a recovery plan by its name
+func (c *RecoveryPlanClient) GetByName(ctx context.Context, name string) (*schema.RecoveryPlanIntent, error) {
+	list, err := c.List(ctx, &schema.DSMetadata{Filter: fmt.Sprintf("name==%s", name)})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Entities) == 0 {
+		return nil, fmt.Errorf("recovery plan not found: %s", name)
+	}
+	return list.Entities[0], err
+}
+
+// List returns a list of recovery plans
+func (c *RecoveryPlanClient) List(ctx context.Context, opts *schema.DSMetadata) (*schema.RecoveryPlanListIntent, error) {
+	response := new(schema.RecoveryPlanListIntent)
+	err := c.client.requestHelper(ctx, recoveryPlanListPath, http.MethodPost, opts, response)
+	return response, err
+
+}
+
+// All returns all recovery plans
+func (c *RecoveryPlanClient) All(ctx context.Context) (*schema.RecoveryPlanListIntent, error) {
+	return c.List(ctx, &schema.DSMetadata{Length: utils.Int64Ptr(itemsPerPage), Offset: utils.Int64Ptr(0)})
+}
+
+// Create creates a recovery plan
+func (c *RecoveryPlanClient) Create(ctx context.Context, createRequest *schema.RecoveryPlanIntent) (*schema.RecoveryPlanIntent, error) {
+	response := new(schema.RecoveryPlanIntent)
+	err := c.client.requestHelper(ctx, recoveryPlanBasePath, http.MethodPost, createRequest, response)
+	return response, err
+}
+
+// Update a recovery plan
+func (c *RecoveryPlanClient) Update(ctx context.Context, recoveryPlan *schema.RecoveryPlanIntent) (*schema.RecoveryPlanIntent, error) {
+	response := new(schema.RecoveryPlanIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(recoveryPlanSinglePath, recoveryPlan.Metadata.UUID), http.MethodPut, recoveryPlan.ToUpdateRequest(), response)
+	return response, err
+}
+
+// UpdateWith fetches the latest recovery plan, applies mutate to it and updates it. On a
+// spec_version conflict the recovery plan is fetched again and mutate is re-applied.
+// It returns the updated recovery plan and the uuid of the update task.
+func (c *RecoveryPlanClient) UpdateWith(ctx context.Context, uuid string, mutate func(*schema.RecoveryPlanIntent) error) (*schema.RecoveryPlanIntent, string, error) {
+	var response *schema.RecoveryPlanIntent
+	err := c.client.retryOnConflict(ctx, func() error {
+		recoveryPlan, err := c.GetByUUID(ctx, uuid)
+		if err != nil {
+			return err
+		}
+		if err = mutate(recoveryPlan); err != nil {
+			return err
+		}
+		response, err = c.Update(ctx, recoveryPlan)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	var taskUUID string
+	if response.Status != nil {
+		taskUUID = response.Status.ExecutionContext.GetTaskUUID()
+	}
+	return response, taskUUID, nil
+}
+
+// Delete deletes a recovery plan
+func (c *RecoveryPlanClient) Delete(ctx context.Context, uuid string) error {
+	return c.client.requestHelper(ctx, fmt.Sprintf(recoveryPlanSinglePath, uuid), http.MethodDelete, nil, nil)
+}
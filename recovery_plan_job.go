@@ -0,0 +1,190 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/pkg/utils"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+const (
+	recoveryPlanJobBasePath    = "/recovery_plan_jobs"
+	recoveryPlanJobListPath    = recoveryPlanJobBasePath + "/list"
+	recoveryPlanJobSinglePath  = recoveryPlanJobBasePath + "/%s"
+	recoveryPlanJobCleanupPath = recoveryPlanJobSinglePath + "/cleanup"
+
+	recoveryPlanJobStatusCompleted      = "COMPLETED"
+	recoveryPlanJobStatusFailed         = "FAILED"
+	recoveryPlanJobStatusPartialSuccess = "PARTIAL_SUCCESS"
+	recoveryPlanJobStatusAborted        = "ABORTED"
+	recoveryPlanJobStatusCancelled      = "CANCELLED"
+)
+
+// RecoveryPlanJobClient is a client for the recovery plan job API.
+type RecoveryPlanJobClient struct {
+	client *Client
+}
+
+// RecoveryPlanJobError is returned by Wait if a recovery plan job did not complete successfully
+type RecoveryPlanJobError struct {
+	Job *schema.RecoveryPlanJobIntent
+}
+
+func (e *RecoveryPlanJobError) Error() string {
+	var status string
+	var messages []string
+	if e.Job.Status != nil && e.Job.Status.Resources != nil {
+		resources := e.Job.Status.Resources
+		if resources.ExecutionStatus != nil {
+			status = resources.ExecutionStatus.Status
+		}
+		if resources.ValidationInformation != nil {
+			for _, validationError := range resources.ValidationInformation.ErrorsList {
+				messages = append(messages, validationError.Message)
+			}
+		}
+	}
+	if len(messages) == 0 {
+		return fmt.Sprintf("recovery plan job %s: %s", e.Job.Metadata.UUID, status)
+	}
+	return fmt.Sprintf("recovery plan job %s: %s: %s", e.Job.Metadata.UUID, status, strings.Join(messages, "; "))
+}
+
+// Get retrieves a recovery plan job by its UUID if the input can be parsed as an uuid, otherwise it
+// retrieves a recovery plan job by its name
+func (c *RecoveryPlanJobClient) Get(ctx context.Context, idOrName string) (*schema.RecoveryPlanJobIntent, error) {
+	if utils.IsValidUUID(idOrName) {
+		return c.GetByUUID(ctx, idOrName)
+	}
+	return c.GetByName(ctx, idOrName)
+}
+
+// GetByUUID retrieves a recovery plan job by its UUID
+func (c *RecoveryPlanJobClient) GetByUUID(ctx context.Context, uuid string) (*schema.RecoveryPlanJobIntent, error) {
+	response := new(schema.RecoveryPlanJobIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(recoveryPlanJobSinglePath, uuid), http.MethodGet, nil, response)
+	return response, err
+}
+
+// GetByName retrieves a recovery plan job by its name
+func (c *RecoveryPlanJobClient) GetByName(ctx context.Context, name string) (*schema.RecoveryPlanJobIntent, error) {
+	list, err := c.List(ctx, &schema.DSMetadata{Filter: fmt.Sprintf("name==%s", name)})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Entities) == 0 {
+		return nil, fmt.Errorf("recovery plan job not found: %s", name)
+	}
+	return list.Entities[0], err
+}
+
+// List returns a list of recovery plan jobs
+func (c *RecoveryPlanJobClient) List(ctx context.Context, opts *schema.DSMetadata) (*schema.RecoveryPlanJobListIntent, error) {
+	response := new(schema.RecoveryPlanJobListIntent)
+	err := c.client.requestHelper(ctx, recoveryPlanJobListPath, http.MethodPost, opts, response)
+	return response, err
+}
+
+// All returns all recovery plan jobs
+func (c *RecoveryPlanJobClient) All(ctx context.Context) (*schema.RecoveryPlanJobListIntent, error) {
+	return c.List(ctx, &schema.DSMetadata{Length: utils.Int64Ptr(itemsPerPage), Offset: utils.Int64Ptr(0)})
+}
+
+// Create creates a recovery plan job, which starts executing immediately
+func (c *RecoveryPlanJobClient) Create(ctx context.Context, createRequest *schema.RecoveryPlanJobIntent) (*schema.RecoveryPlanJobIntent, error) {
+	response := new(schema.RecoveryPlanJobIntent)
+	err := c.client.requestHelper(ctx, recoveryPlanJobBasePath, http.MethodPost, createRequest, response)
+	return response, err
+}
+
+// Start creates a job running actionType, one of the schema.RecoveryPlanJobAction* constants,
+// for the recovery plan. Entities are recovered from failed to recovery.
+func (c *RecoveryPlanJobClient) Start(ctx context.Context, plan *schema.RecoveryPlanIntent, actionType string, failed, recovery *schema.RecoveryPlanJobAvailabilityZone) (*schema.RecoveryPlanJobIntent, error) {
+	var planName string
+	if plan.Spec != nil {
+		planName = plan.Spec.Name
+	}
+	return c.Create(ctx, &schema.RecoveryPlanJobIntent{
+		Metadata: &schema.Metadata{Kind: "recovery_plan_job"},
+		Spec: &schema.RecoveryPlanJob{
+			Name: fmt.Sprintf("%s-%s-%d", planName, strings.ToLower(actionType), time.Now().Unix()),
+			Resources: &schema.RecoveryPlanJobResources{
+				RecoveryPlanReference: &schema.Reference{Kind: "recovery_plan", UUID: plan.Metadata.UUID},
+				ExecutionParameters: &schema.RecoveryPlanJobExecutionParameters{
+					ActionType:                   actionType,
+					FailedAvailabilityZoneList:   []*schema.RecoveryPlanJobAvailabilityZone{failed},
+					RecoveryAvailabilityZoneList: []*schema.RecoveryPlanJobAvailabilityZone{recovery},
+				},
+			},
+		},
+	})
+}
+
+// Validate starts a job validating the recovery plan can fail over from failed to recovery
+func (c *RecoveryPlanJobClient) Validate(ctx context.Context, plan *schema.RecoveryPlanIntent, failed, recovery *schema.RecoveryPlanJobAvailabilityZone) (*schema.RecoveryPlanJobIntent, error) {
+	return c.Start(ctx, plan, schema.RecoveryPlanJobActionValidate, failed, recovery)
+}
+
+// TestFailover starts a test failover to the test networks of the recovery plan. Clean it up
+// with Cleanup.
+func (c *RecoveryPlanJobClient) TestFailover(ctx context.Context, plan *schema.RecoveryPlanIntent, failed, recovery *schema.RecoveryPlanJobAvailabilityZone) (*schema.RecoveryPlanJobIntent, error) {
+	return c.Start(ctx, plan, schema.RecoveryPlanJobActionTestFailover, failed, recovery)
+}
+
+// PlannedFailover starts a planned failover, which shuts down and replicates the entities before
+// recovering them
+func (c *RecoveryPlanJobClient) PlannedFailover(ctx context.Context, plan *schema.RecoveryPlanIntent, failed, recovery *schema.RecoveryPlanJobAvailabilityZone) (*schema.RecoveryPlanJobIntent, error) {
+	return c.Start(ctx, plan, schema.RecoveryPlanJobActionMigrate, failed, recovery)
+}
+
+// UnplannedFailover starts an unplanned failover, which recovers the entities from the latest
+// recovery points available at recovery
+func (c *RecoveryPlanJobClient) UnplannedFailover(ctx context.Context, plan *schema.RecoveryPlanIntent, failed, recovery *schema.RecoveryPlanJobAvailabilityZone) (*schema.RecoveryPlanJobIntent, error) {
+	return c.Start(ctx, plan, schema.RecoveryPlanJobActionFailover, failed, recovery)
+}
+
+// Cleanup removes the entities created by a test failover job and waits for the cleanup task
+func (c *RecoveryPlanJobClient) Cleanup(ctx context.Context, job *schema.RecoveryPlanJobIntent) error {
+	response := new(schema.ExecutionContext)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(recoveryPlanJobCleanupPath, job.Metadata.UUID), http.MethodPost, struct{}{}, response)
+	if err != nil {
+		return err
+	}
+	_, err = c.client.Task.Wait(ctx, response.GetTaskUUID())
+	return err
+}
+
+// Wait polls a recovery plan job until it has finished. A *RecoveryPlanJobError is returned if
+// the job did not complete successfully.
+func (c *RecoveryPlanJobClient) Wait(ctx context.Context, uuid string) (*schema.RecoveryPlanJobIntent, error) {
+	ticker := time.NewTicker(taskPollInterval)
+	defer ticker.Stop()
+
+	for {
+		job, err := c.GetByUUID(ctx, uuid)
+		if err != nil {
+			return nil, err
+		}
+
+		var status string
+		if job.Status != nil && job.Status.Resources != nil && job.Status.Resources.ExecutionStatus != nil {
+			status = job.Status.Resources.ExecutionStatus.Status
+		}
+		switch status {
+		case recoveryPlanJobStatusCompleted:
+			return job, nil
+		case recoveryPlanJobStatusFailed, recoveryPlanJobStatusPartialSuccess, recoveryPlanJobStatusAborted, recoveryPlanJobStatusCancelled:
+			return job, &RecoveryPlanJobError{Job: job}
+		}
+
+		select {
+		case <-ctx.Done():
+			return job, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/pkg/utils"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+const (
+	roleBasePath   = "/roles"
+	roleListPath   = roleBasePath + "/list"
+	roleSinglePath = roleBasePath + "/%s"
+)
+
+// RoleClient is a client for the role API.
+type RoleClient struct {
+	client *Client
+}
+
+// Get retrieves a role by its UUID if the input can be parsed as an uuid, otherwise it
+// retrieves a role by its name
+func (c *RoleClient) Get(ctx context.Context, idOrName string) (*schema.RoleIntent, error) {
+	if utils.IsValidUUID(idOrName) {
+		return c.GetByUUID(ctx, idOrName)
+	}
+	return c.GetByName(ctx, idOrName)
+}
+
+// GetByUUID retrieves a role by its UUID
+func (c *RoleClient) GetByUUID(ctx context.Context, uuid string) (*schema.RoleIntent, error) {
+	response := new(schema.RoleIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(roleSinglePath, uuid), http.MethodGet, nil, response)
+	return response, err
+}
+
+// GetByName retrieves a role by its name
+func (c *RoleClient) GetByName(ctx context.Context, name string) (*schema.RoleIntent, error) {
+	list, err := c.List(ctx, &schema.DSMetadata{Filter: fmt.Sprintf("name==%s", name)})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Entities) == 0 {
+		return nil, fmt.Errorf("role not found: %s", name)
+	}
+	return list.Entities[0], err
+}
+
+// List returns a list of roles
+func (c *RoleClient) List(ctx context.Context, opts *schema.DSMetadata) (*schema.RoleListIntent, error) {
+	response := new(schema.RoleListIntent)
+	err := c.client.requestHelper(ctx, roleListPath, http.MethodPost, opts, response)
+	return response, err
+
+}
+
+// All returns all roles
+func (c *RoleClient) All(ctx context.Context) (*schema.RoleListIntent, error) {
+	return c.List(ctx, &schema.DSMetadata{Length: utils.Int64Ptr(itemsPerPage), Offset: utils.Int64Ptr(0)})
+}
+
+// Create creates a role
+func (c *RoleClient) Create(ctx context.Context, createRequest *schema.RoleIntent) (*schema.RoleIntent, error) {
+	response := new(schema.RoleIntent)
+	err := c.client.requestHelper(ctx, roleBasePath, http.MethodPost, createRequest, response)
+	return response, err
+}
+
+// Update a role
+func (c *RoleClient) Update(ctx context.Context, role *schema.RoleIntent) (*schema.RoleIntent, error) {
+	response := new(schema.RoleIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(roleSinglePath, role.Metadata.UUID), http.MethodPut, role.ToUpdateRequest(), response)
+	return response, err
+}
+
+// UpdateWith fetches the latest role, applies mutate to it and updates it. On a
+// spec_version conflict the role is fetched again and mutate is re-applied.
+// It returns the updated role and the uuid of the update task.
+func (c *RoleClient) UpdateWith(ctx context.Context, uuid string, mutate func(*schema.RoleIntent) error) (*schema.RoleIntent, string, error) {
+	var response *schema.RoleIntent
+	err := c.client.retryOnConflict(ctx, func() error {
+		role, err := c.GetByUUID(ctx, uuid)
+		if err != nil {
+			return err
+		}
+		if err = mutate(role); err != nil {
+			return err
+		}
+		response, err = c.Update(ctx, role)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	var taskUUID string
+	if response.Status != nil {
+		taskUUID = response.Status.ExecutionContext.GetTaskUUID()
+	}
+	return response, taskUUID, nil
+}
+
+// Delete deletes a role
+func (c *RoleClient) Delete(ctx context.Context, uuid string) error {
+	return c.client.requestHelper(ctx, fmt.Sprintf(roleSinglePath, uuid), http.MethodDelete, nil, nil)
+}
@@ -49,13 +49,70 @@ func (c *RoutingPolicyClient) Create(ctx context.Context, createRequest *schema.
 
 // Update a FlotatingIp
 func (c *RoutingPolicyClient) Update(ctx context.Context, r *schema.RoutingPolicyIntent) (*schema.RoutingPolicyIntent, error) {
-	r.Status = nil
 	response := new(schema.RoutingPolicyIntent)
-	err := c.client.requestHelper(ctx, fmt.Sprintf(routingPolicySinglePath, r.Metadata.UUID), http.MethodPut, r, response)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(routingPolicySinglePath, r.Metadata.UUID), http.MethodPut, r.ToUpdateRequest(), response)
 	return response, err
 }
 
+// UpdateWith fetches the latest routing policy, applies mutate to it and updates it. On a
+// spec_version conflict the routing policy is fetched again and mutate is re-applied.
+// It returns the updated routing policy and the uuid of the update task.
+func (c *RoutingPolicyClient) UpdateWith(ctx context.Context, uuid string, mutate func(*schema.RoutingPolicyIntent) error) (*schema.RoutingPolicyIntent, string, error) {
+	var response *schema.RoutingPolicyIntent
+	err := c.client.retryOnConflict(ctx, func() error {
+		r, err := c.GetByUUID(ctx, uuid)
+		if err != nil {
+			return err
+		}
+		if err = mutate(r); err != nil {
+			return err
+		}
+		response, err = c.Update(ctx, r)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	var taskUUID string
+	if response.Status != nil {
+		taskUUID = response.Status.ExecutionContext.GetTaskUUID()
+	}
+	return response, taskUUID, nil
+}
+
 // Delete deletes a FlotatingIp
 func (c *RoutingPolicyClient) Delete(ctx context.Context, uuid string) error {
 	return c.client.requestHelper(ctx, fmt.Sprintf(routingPolicySinglePath, uuid), http.MethodDelete, nil, nil)
 }
+
+// MigrateToVpc replaces the deprecated virtual network reference of a routing policy with the
+// reference of the vpc replacing the virtual network and waits for the update task
+func (c *RoutingPolicyClient) MigrateToVpc(ctx context.Context, uuid string) (*schema.RoutingPolicyIntent, error) {
+	policy, err := c.GetByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if policy.Spec == nil || policy.Spec.Resources == nil || policy.Spec.Resources.VirtualNetworkReference == nil {
+		return policy, nil
+	}
+	vpcRef, err := c.client.VirtualNetwork.VpcReference(ctx, policy.Spec.Resources.VirtualNetworkReference)
+	if err != nil {
+		return nil, err
+	}
+
+	_, taskUUID, err := c.UpdateWith(ctx, uuid, func(policy *schema.RoutingPolicyIntent) error {
+		if policy.Spec == nil || policy.Spec.Resources == nil {
+			return fmt.Errorf("routing policy %s has no spec resources", uuid)
+		}
+		policy.Spec.Resources.VpcReference = vpcRef
+		policy.Spec.Resources.VirtualNetworkReference = nil
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = c.client.Task.Wait(ctx, taskUUID); err != nil {
+		return nil, err
+	}
+	return c.GetByUUID(ctx, uuid)
+}
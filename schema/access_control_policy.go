@@ -0,0 +1,170 @@
+package schema
+
+const (
+	FilterOperatorIn    = "IN"
+	FilterOperatorNotIn = "NOT_IN"
+
+	// ScopeProject is the left hand side of a scope filter limiting a policy to projects
+	ScopeProject = "PROJECT"
+
+	// EntityTypeAll is the entity type of an entity filter matching every entity
+	EntityTypeAll = "ALL"
+
+	// CollectionAll is the collection of a right hand side matching every entity
+	CollectionAll = "ALL"
+)
+
+type AccessControlPolicy struct {
+
+	// A description for the access control policy.
+	// Max Length: 1000
+	Description string `json:"description,omitempty"`
+
+	// access control policy Name.
+	// Required: true
+	// Max Length: 64
+	Name string `json:"name"`
+
+	// resources
+	// Required: true
+	Resources *AccessControlPolicyResources `json:"resources"`
+}
+
+type AccessControlPolicyResources struct {
+
+	// The role granted by the policy
+	// Required: true
+	RoleReference *Reference `json:"role_reference"`
+
+	// The users the role is granted to
+	UserReferenceList []*Reference `json:"user_reference_list,omitempty"`
+
+	// The user groups the role is granted to
+	UserGroupReferenceList []*Reference `json:"user_group_reference_list,omitempty"`
+
+	// The entities the role is granted on
+	FilterList *FilterList `json:"filter_list,omitempty"`
+}
+
+type FilterList struct {
+
+	// The policy applies to entities matching any of the contexts
+	ContextList []*FilterContext `json:"context_list,omitempty"`
+}
+
+type FilterContext struct {
+
+	// Scope filters are combined with AND, for example limiting the context to a project
+	ScopeFilterExpressionList []*ScopeFilterExpression `json:"scope_filter_expression_list,omitempty"`
+
+	// Entity filters are combined with OR
+	// Required: true
+	EntityFilterExpressionList []*EntityFilterExpression `json:"entity_filter_expression_list"`
+}
+
+type ScopeFilterExpression struct {
+
+	// The scope, for example PROJECT
+	// Required: true
+	LeftHandSide string `json:"left_hand_side"`
+
+	// IN or NOT_IN
+	// Required: true
+	Operator string `json:"operator"`
+
+	// Required: true
+	RightHandSide *FilterRightHandSide `json:"right_hand_side"`
+}
+
+type EntityFilterExpression struct {
+
+	// Required: true
+	LeftHandSide *EntityFilterLeftHandSide `json:"left_hand_side"`
+
+	// IN or NOT_IN
+	// Required: true
+	Operator string `json:"operator"`
+
+	// Required: true
+	RightHandSide *FilterRightHandSide `json:"right_hand_side"`
+}
+
+type EntityFilterLeftHandSide struct {
+
+	// The entity type, for example vm or ALL
+	EntityType string `json:"entity_type"`
+}
+
+type FilterRightHandSide struct {
+
+	// A collection of entities, for example ALL or SELF_OWNED
+	Collection string `json:"collection,omitempty"`
+
+	// Entities with these categories
+	Categories map[string][]string `json:"categories,omitempty"`
+
+	// Entities with these uuids
+	UUIDList []string `json:"uuid_list,omitempty"`
+}
+
+type AccessControlPolicyDefStatus struct {
+
+	// A description for the access control policy.
+	Description string `json:"description,omitempty"`
+
+	// Any error messages for the access control policy, if in an error state.
+	MessageList []*MessageResource `json:"message_list,omitempty"`
+
+	// name
+	Name string `json:"name,omitempty"`
+
+	// resources
+	Resources *AccessControlPolicyResources `json:"resources,omitempty"`
+
+	// The state of the access control policy.
+	State string `json:"state,omitempty"`
+
+	ExecutionContext *ExecutionContext `json:"execution_context,omitempty"`
+}
+
+type AccessControlPolicyIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *AccessControlPolicy `json:"spec,omitempty"`
+
+	// status
+	Status *AccessControlPolicyDefStatus `json:"status,omitempty"`
+}
+
+type AccessControlPolicyIntentRequest struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *AccessControlPolicy `json:"spec,omitempty"`
+}
+
+type AccessControlPolicyListIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// entities
+	Entities []*AccessControlPolicyIntent `json:"entities"`
+
+	// metadata
+	// Required: true
+	Metadata *ListMetadata `json:"metadata"`
+}
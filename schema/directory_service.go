@@ -0,0 +1,163 @@
+package schema
+
+const (
+	DirectoryTypeActiveDirectory = "ACTIVE_DIRECTORY"
+	DirectoryTypeOpenLDAP        = "OPEN_LDAP"
+
+	// Search result types
+	DirectoryEntryTypePerson = "person"
+	DirectoryEntryTypeGroup  = "group"
+)
+
+type DirectoryService struct {
+
+	// directory service Name.
+	// Required: true
+	// Max Length: 64
+	Name string `json:"name"`
+
+	// resources
+	// Required: true
+	Resources *DirectoryServiceResources `json:"resources"`
+}
+
+type DirectoryServiceResources struct {
+
+	// The LDAP url of the directory, for example ldap://ad.example.com:389
+	// Required: true
+	URL string `json:"url"`
+
+	// The domain name of the directory, for example example.com
+	DomainName string `json:"domain_name,omitempty"`
+
+	// ACTIVE_DIRECTORY or OPEN_LDAP
+	DirectoryType string `json:"directory_type,omitempty"`
+
+	// The account used to query the directory
+	ServiceAccount *DirectoryServiceAccount `json:"service_account,omitempty"`
+
+	// NON_RECURSIVE or RECURSIVE group membership lookup
+	GroupSearchType string `json:"group_search_type,omitempty"`
+
+	// Users of the directory with cluster admin privileges
+	AdminUserReferenceList []*Reference `json:"admin_user_reference_list,omitempty"`
+
+	// Groups of the directory with cluster admin privileges
+	AdminGroupReferenceList []*Reference `json:"admin_group_reference_list,omitempty"`
+}
+
+type DirectoryServiceAccount struct {
+
+	// Required: true
+	Username string `json:"username"`
+
+	// Required: true
+	Password string `json:"password"`
+}
+
+type DirectoryServiceDefStatus struct {
+
+	// Any error messages for the directory service, if in an error state.
+	MessageList []*MessageResource `json:"message_list,omitempty"`
+
+	// name
+	Name string `json:"name,omitempty"`
+
+	// resources
+	Resources *DirectoryServiceResources `json:"resources,omitempty"`
+
+	// The state of the directory service.
+	State string `json:"state,omitempty"`
+
+	ExecutionContext *ExecutionContext `json:"execution_context,omitempty"`
+}
+
+type DirectoryServiceIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *DirectoryService `json:"spec,omitempty"`
+
+	// status
+	Status *DirectoryServiceDefStatus `json:"status,omitempty"`
+}
+
+type DirectoryServiceIntentRequest struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *DirectoryService `json:"spec,omitempty"`
+}
+
+type DirectoryServiceListIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// entities
+	Entities []*DirectoryServiceIntent `json:"entities"`
+
+	// metadata
+	// Required: true
+	Metadata *ListMetadata `json:"metadata"`
+}
+
+// DirectoryServiceSearchRequest searches users and groups of a directory service
+type DirectoryServiceSearchRequest struct {
+
+	// The value searched for
+	// Required: true
+	Query string `json:"query"`
+
+	// The attributes the query is matched against, for example userPrincipalName
+	SearchedAttributeList []string `json:"searched_attribute_list,omitempty"`
+
+	// The attributes returned for each entry
+	ReturnedAttributeList []string `json:"returned_attribute_list,omitempty"`
+
+	// Match the query as a prefix instead of exactly
+	IsWildcardSearch bool `json:"is_wildcard_search,omitempty"`
+}
+
+type DirectoryServiceSearchResponse struct {
+	DomainName       string                          `json:"domain_name,omitempty"`
+	SearchResultList []*DirectoryServiceSearchResult `json:"search_result_list,omitempty"`
+}
+
+type DirectoryServiceSearchResult struct {
+
+	// The distinguished name of the entry
+	Name string `json:"name,omitempty"`
+
+	// person or group
+	Type string `json:"type,omitempty"`
+
+	AttributeList []*DirectoryServiceAttribute `json:"attribute_list,omitempty"`
+}
+
+type DirectoryServiceAttribute struct {
+	Name      string   `json:"name,omitempty"`
+	ValueList []string `json:"value_list,omitempty"`
+}
+
+// Attribute returns the first value of the attribute, empty if the entry has none
+func (r *DirectoryServiceSearchResult) Attribute(name string) string {
+	for _, attribute := range r.AttributeList {
+		if attribute.Name == name && len(attribute.ValueList) > 0 {
+			return attribute.ValueList[0]
+		}
+	}
+	return ""
+}
@@ -59,6 +59,19 @@ type FloatingIPIntent struct {
 	Status *FloatingIPDefStatus `json:"status,omitempty"`
 }
 
+type FloatingIPIntentRequest struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *FloatingIP `json:"spec,omitempty"`
+}
+
 // FloatingIPDefStatus floating_ip Intent Status with placement specified
 //
 // An intentful representation of a floating_ip status
@@ -88,6 +101,8 @@ type FloatingIPDefStatus struct {
 
 	// The state of the floating_ip.
 	State string `json:"state,omitempty"`
+
+	ExecutionContext *ExecutionContext `json:"execution_context,omitempty"`
 }
 
 // FloatingIPListIntentResponse Entity Intent List Response
@@ -0,0 +1,111 @@
+package schema
+
+const (
+	GroupsSortOrderAscending  = "ASCENDING"
+	GroupsSortOrderDescending = "DESCENDING"
+)
+
+// GroupsRequest queries attributes of entities through the groups (analytics) API
+type GroupsRequest struct {
+
+	// Entity type, for example mh_vm, host or cluster
+	// Required: true
+	EntityType string `json:"entity_type"`
+
+	// Limit the query to these entity uuids
+	EntityIDs []string `json:"entity_ids,omitempty"`
+
+	// Attributes returned for every entity
+	GroupMemberAttributes []*GroupsAttribute `json:"group_member_attributes,omitempty"`
+
+	// Filter in FIQL syntax, for example power_state==on
+	FilterCriteria string `json:"filter_criteria,omitempty"`
+
+	GroupMemberSortAttribute string `json:"group_member_sort_attribute,omitempty"`
+
+	// ASCENDING or DESCENDING
+	GroupMemberSortOrder string `json:"group_member_sort_order,omitempty"`
+
+	// Number of entities returned
+	GroupMemberCount *int64 `json:"group_member_count,omitempty"`
+
+	// Offset of the first entity returned
+	GroupMemberOffset *int64 `json:"group_member_offset,omitempty"`
+}
+
+type GroupsAttribute struct {
+	Attribute string `json:"attribute"`
+}
+
+// GroupsResponse is the response of a groups query
+type GroupsResponse struct {
+	EntityType string `json:"entity_type,omitempty"`
+
+	// Number of entities matching the filter
+	FilteredEntityCount int64 `json:"filtered_entity_count,omitempty"`
+
+	TotalEntityCount   int64 `json:"total_entity_count,omitempty"`
+	FilteredGroupCount int64 `json:"filtered_group_count,omitempty"`
+	TotalGroupCount    int64 `json:"total_group_count,omitempty"`
+
+	GroupResults []*GroupsGroupResult `json:"group_results,omitempty"`
+}
+
+type GroupsGroupResult struct {
+	GroupByColumnValue string                `json:"group_by_column_value,omitempty"`
+	TotalEntityCount   int64                 `json:"total_entity_count,omitempty"`
+	EntityResults      []*GroupsEntityResult `json:"entity_results,omitempty"`
+}
+
+type GroupsEntityResult struct {
+	EntityID string             `json:"entity_id,omitempty"`
+	Data     []*GroupsFieldData `json:"data,omitempty"`
+}
+
+type GroupsFieldData struct {
+	Name   string              `json:"name,omitempty"`
+	Values []*GroupsFieldValue `json:"values,omitempty"`
+}
+
+type GroupsFieldValue struct {
+	Values []string `json:"values,omitempty"`
+
+	// Sample time in microseconds
+	Time int64 `json:"time,omitempty"`
+}
+
+// Entities returns the entities of all groups of the response
+func (r *GroupsResponse) Entities() []*GroupsEntityResult {
+	var entities []*GroupsEntityResult
+	for _, group := range r.GroupResults {
+		entities = append(entities, group.EntityResults...)
+	}
+	return entities
+}
+
+// Values returns the most recent values of the attribute, nil if the entity has none
+func (e *GroupsEntityResult) Values(attribute string) []string {
+	for _, data := range e.Data {
+		if data.Name == attribute && len(data.Values) > 0 {
+			return data.Values[0].Values
+		}
+	}
+	return nil
+}
+
+// Value returns the first of the most recent values of the attribute, empty if the entity has none
+func (e *GroupsEntityResult) Value(attribute string) string {
+	if values := e.Values(attribute); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Map returns the most recent value of every attribute of the entity
+func (e *GroupsEntityResult) Map() map[string]string {
+	m := make(map[string]string, len(e.Data))
+	for _, data := range e.Data {
+		m[data.Name] = e.Value(data.Name)
+	}
+	return m
+}
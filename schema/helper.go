@@ -30,3 +30,22 @@ func (m *MetaData) ToBase64() (string, error) {
 	}
 	return base64.StdEncoding.EncodeToString(j), nil
 }
+
+// GetTaskUUID returns the task uuid of the execution context. The API returns
+// either a single uuid or a list of uuids, in which case the first is returned.
+func (e *ExecutionContext) GetTaskUUID() string {
+	if e == nil {
+		return ""
+	}
+	switch v := e.TaskUUID.(type) {
+	case string:
+		return v
+	case []interface{}:
+		if len(v) > 0 {
+			if s, ok := v[0].(string); ok {
+				return s
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+package schema
+
+type IdentityProvider struct {
+
+	// A description for the identity provider.
+	Description string `json:"description,omitempty"`
+
+	// identity provider Name.
+	// Required: true
+	// Max Length: 64
+	Name string `json:"name"`
+
+	// resources
+	// Required: true
+	Resources *IdentityProviderResources `json:"resources"`
+}
+
+type IdentityProviderResources struct {
+
+	// The url the SAML metadata of the identity provider is fetched from
+	IdpMetadataURL string `json:"idp_metadata_url,omitempty"`
+
+	// The SAML metadata xml of the identity provider
+	IdpMetadata string `json:"idp_metadata,omitempty"`
+
+	// The identity provider properties, if no metadata is given
+	IdpProperties *IdentityProviderProperties `json:"idp_properties,omitempty"`
+
+	// SAML assertion attributes
+	UsernameAttribute string `json:"username_attribute,omitempty"`
+	EmailAttribute    string `json:"email_attribute,omitempty"`
+	GroupsAttribute   string `json:"groups_attribute,omitempty"`
+	GroupsDelim       string `json:"groups_delim,omitempty"`
+}
+
+type IdentityProviderProperties struct {
+	IdpURL      string `json:"idp_url,omitempty"`
+	LoginURL    string `json:"login_url,omitempty"`
+	LogoutURL   string `json:"logout_url,omitempty"`
+	ErrorURL    string `json:"error_url,omitempty"`
+	Certificate string `json:"certificate,omitempty"`
+}
+
+type IdentityProviderDefStatus struct {
+
+	// A description for the identity provider.
+	Description string `json:"description,omitempty"`
+
+	// Any error messages for the identity provider, if in an error state.
+	MessageList []*MessageResource `json:"message_list,omitempty"`
+
+	// name
+	Name string `json:"name,omitempty"`
+
+	// resources
+	Resources *IdentityProviderResources `json:"resources,omitempty"`
+
+	// The state of the identity provider.
+	State string `json:"state,omitempty"`
+
+	ExecutionContext *ExecutionContext `json:"execution_context,omitempty"`
+}
+
+type IdentityProviderIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *IdentityProvider `json:"spec,omitempty"`
+
+	// status
+	Status *IdentityProviderDefStatus `json:"status,omitempty"`
+}
+
+type IdentityProviderIntentRequest struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *IdentityProvider `json:"spec,omitempty"`
+}
+
+type IdentityProviderListIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// entities
+	Entities []*IdentityProviderIntent `json:"entities"`
+
+	// metadata
+	// Required: true
+	Metadata *ListMetadata `json:"metadata"`
+}
@@ -28,6 +28,8 @@ type ProjectDefStatus struct {
 
 	// The state of the project entity.
 	State string `json:"state,omitempty"`
+
+	ExecutionContext *ExecutionContext `json:"execution_context,omitempty"`
 }
 
 type Project struct {
@@ -98,6 +100,13 @@ type ResourceDomainResourcesStatus struct {
 	Resources []*ResourceUtilizationStatus `json:"resources"`
 }
 
+// Resource types of the project resource domain
+const (
+	ResourceTypeVCPUs   = "VCPUS"
+	ResourceTypeMemory  = "MEMORY"
+	ResourceTypeStorage = "STORAGE"
+)
+
 type ResourceUtilizationSpec struct {
 	// The resource consumption limit
 	Limit int64 `json:"limit,omitempty"`
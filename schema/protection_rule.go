@@ -0,0 +1,167 @@
+package schema
+
+const (
+	CategoryFilterMatchAny = "CATEGORIES_MATCH_ANY"
+	CategoryFilterMatchAll = "CATEGORIES_MATCH_ALL"
+
+	SnapshotIntervalTypeHourly  = "HOURLY"
+	SnapshotIntervalTypeDaily   = "DAILY"
+	SnapshotIntervalTypeWeekly  = "WEEKLY"
+	SnapshotIntervalTypeMonthly = "MONTHLY"
+	SnapshotIntervalTypeYearly  = "YEARLY"
+)
+
+type ProtectionRule struct {
+
+	// A description for the protection rule.
+	// Max Length: 1000
+	Description string `json:"description,omitempty"`
+
+	// protection rule Name.
+	// Required: true
+	// Max Length: 64
+	Name string `json:"name"`
+
+	// resources
+	// Required: true
+	Resources *ProtectionRuleResources `json:"resources"`
+}
+
+type ProtectionRuleResources struct {
+
+	// Time of the day the first snapshot is taken, for example 22h:00m
+	StartTime string `json:"start_time,omitempty"`
+
+	// The availability zones and clusters the rule applies to. Connectivities refer to them by index.
+	// Required: true
+	OrderedAvailabilityZoneList []*OrderedAvailabilityZone `json:"ordered_availability_zone_list"`
+
+	// The replication schedules between the availability zones
+	// Required: true
+	AvailabilityZoneConnectivityList []*AvailabilityZoneConnectivity `json:"availability_zone_connectivity_list"`
+
+	// The entities protected by the rule
+	CategoryFilter *CategoryFilter `json:"category_filter,omitempty"`
+}
+
+type OrderedAvailabilityZone struct {
+
+	// The url of the availability zone
+	// Required: true
+	AvailabilityZoneURL string `json:"availability_zone_url"`
+
+	// A cluster of the availability zone, all clusters if empty
+	ClusterUUID string `json:"cluster_uuid,omitempty"`
+}
+
+type AvailabilityZoneConnectivity struct {
+
+	// Index of the source in OrderedAvailabilityZoneList
+	SourceAvailabilityZoneIndex *int64 `json:"source_availability_zone_index,omitempty"`
+
+	// Index of the destination in OrderedAvailabilityZoneList
+	DestinationAvailabilityZoneIndex *int64 `json:"destination_availability_zone_index,omitempty"`
+
+	SnapshotScheduleList []*SnapshotSchedule `json:"snapshot_schedule_list,omitempty"`
+}
+
+type SnapshotSchedule struct {
+
+	// The RPO of the schedule in seconds
+	// Required: true
+	RecoveryPointObjectiveSecs int64 `json:"recovery_point_objective_secs"`
+
+	// CRASH_CONSISTENT or APPLICATION_CONSISTENT
+	SnapshotType string `json:"snapshot_type,omitempty"`
+
+	// Retention of the recovery points on the source
+	LocalSnapshotRetentionPolicy *SnapshotRetentionPolicy `json:"local_snapshot_retention_policy,omitempty"`
+
+	// Retention of the recovery points on the destination
+	RemoteSnapshotRetentionPolicy *SnapshotRetentionPolicy `json:"remote_snapshot_retention_policy,omitempty"`
+
+	// Replication is suspended if it does not complete within this time
+	AutoSuspendTimeoutSecs *int64 `json:"auto_suspend_timeout_secs,omitempty"`
+}
+
+type SnapshotRetentionPolicy struct {
+
+	// Keep the last n recovery points
+	NumSnapshots *int64 `json:"num_snapshots,omitempty"`
+
+	// Keep recovery points rolled up by interval
+	RollupRetentionPolicy *RollupRetentionPolicy `json:"rollup_retention_policy,omitempty"`
+}
+
+type RollupRetentionPolicy struct {
+
+	// Number of intervals to keep
+	// Required: true
+	Multiple int64 `json:"multiple"`
+
+	// HOURLY, DAILY, WEEKLY, MONTHLY or YEARLY
+	// Required: true
+	SnapshotIntervalType string `json:"snapshot_interval_type"`
+}
+
+type ProtectionRuleDefStatus struct {
+
+	// A description for the protection rule.
+	Description string `json:"description,omitempty"`
+
+	// Any error messages for the protection rule, if in an error state.
+	MessageList []*MessageResource `json:"message_list,omitempty"`
+
+	// name
+	Name string `json:"name,omitempty"`
+
+	// resources
+	Resources *ProtectionRuleResources `json:"resources,omitempty"`
+
+	// The state of the protection rule.
+	State string `json:"state,omitempty"`
+
+	ExecutionContext *ExecutionContext `json:"execution_context,omitempty"`
+}
+
+type ProtectionRuleIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *ProtectionRule `json:"spec,omitempty"`
+
+	// status
+	Status *ProtectionRuleDefStatus `json:"status,omitempty"`
+}
+
+type ProtectionRuleIntentRequest struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *ProtectionRule `json:"spec,omitempty"`
+}
+
+type ProtectionRuleListIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// entities
+	Entities []*ProtectionRuleIntent `json:"entities"`
+
+	// metadata
+	// Required: true
+	Metadata *ListMetadata `json:"metadata"`
+}
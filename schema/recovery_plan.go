@@ -0,0 +1,357 @@
+package schema
+
+const (
+	RecoveryPlanJobActionValidate     = "VALIDATE"
+	RecoveryPlanJobActionTestFailover = "TEST_FAILOVER"
+	RecoveryPlanJobActionMigrate      = "MIGRATE"
+	RecoveryPlanJobActionFailover     = "FAILOVER"
+	RecoveryPlanJobActionLiveMigrate  = "LIVE_MIGRATE"
+)
+
+type RecoveryPlan struct {
+
+	// A description for the recovery plan.
+	// Max Length: 1000
+	Description string `json:"description,omitempty"`
+
+	// recovery plan Name.
+	// Required: true
+	// Max Length: 64
+	Name string `json:"name"`
+
+	// resources
+	// Required: true
+	Resources *RecoveryPlanResources `json:"resources"`
+}
+
+type RecoveryPlanResources struct {
+
+	// The stages are recovered in order
+	// Required: true
+	StageList []*RecoveryPlanStage `json:"stage_list"`
+
+	// parameters
+	// Required: true
+	Parameters *RecoveryPlanParameters `json:"parameters"`
+}
+
+type RecoveryPlanStage struct {
+
+	// uuid of the stage, generated if empty
+	StageUUID string `json:"stage_uuid,omitempty"`
+
+	// Delay after the stage in seconds
+	DelayTimeSecs *int64 `json:"delay_time_secs,omitempty"`
+
+	// Required: true
+	StageWork *RecoveryPlanStageWork `json:"stage_work"`
+}
+
+type RecoveryPlanStageWork struct {
+	RecoverEntities *RecoverEntities `json:"recover_entities,omitempty"`
+}
+
+type RecoverEntities struct {
+	EntityInfoList []*RecoveryPlanEntityInfo `json:"entity_info_list,omitempty"`
+}
+
+type RecoveryPlanEntityInfo struct {
+
+	// A single entity to recover
+	AnyEntityReference *Reference `json:"any_entity_reference,omitempty"`
+
+	// All entities with these categories are recovered
+	Categories map[string]string `json:"categories,omitempty"`
+
+	// Scripts run in the recovered vms
+	ScriptList []*RecoveryPlanScript `json:"script_list,omitempty"`
+}
+
+type RecoveryPlanScript struct {
+	EnableScriptExec *bool  `json:"enable_script_exec,omitempty"`
+	Timeout          *int64 `json:"timeout,omitempty"`
+}
+
+type RecoveryPlanParameters struct {
+
+	// Index of the primary location in the network mappings
+	PrimaryLocationIndex *int64 `json:"primary_location_index,omitempty"`
+
+	// The networks vms are recovered to, by availability zone
+	NetworkMappingList []*NetworkMapping `json:"network_mapping_list,omitempty"`
+
+	FloatingIPAssignmentList []*FloatingIPAssignment `json:"floating_ip_assignment_list,omitempty"`
+}
+
+type NetworkMapping struct {
+
+	// The networks are stretched across the availability zones
+	AreNetworksStretched *bool `json:"are_networks_stretched,omitempty"`
+
+	// Required: true
+	AvailabilityZoneNetworkMappingList []*AvailabilityZoneNetworkMapping `json:"availability_zone_network_mapping_list"`
+}
+
+type AvailabilityZoneNetworkMapping struct {
+
+	// The url of the availability zone
+	AvailabilityZoneURL string `json:"availability_zone_url,omitempty"`
+
+	// The clusters of the availability zone the mapping applies to
+	ClusterReferenceList []*Reference `json:"cluster_reference_list,omitempty"`
+
+	// The network used for failover
+	RecoveryNetwork *RecoveryNetwork `json:"recovery_network,omitempty"`
+
+	// The network used for test failover
+	TestNetwork *RecoveryNetwork `json:"test_network,omitempty"`
+
+	// Static ip addresses of vms after failover
+	RecoveryIPAssignmentList []*IPAssignment `json:"recovery_ip_assignment_list,omitempty"`
+
+	// Static ip addresses of vms after test failover
+	TestIPAssignmentList []*IPAssignment `json:"test_ip_assignment_list,omitempty"`
+}
+
+type RecoveryNetwork struct {
+
+	// Name of the network
+	Name string `json:"name,omitempty"`
+
+	SubnetList []*RecoveryNetworkSubnet `json:"subnet_list,omitempty"`
+
+	VirtualNetworkReference *Reference `json:"virtual_network_reference,omitempty"`
+
+	VpcReference *Reference `json:"vpc_reference,omitempty"`
+}
+
+type RecoveryNetworkSubnet struct {
+	GatewayIP                 string `json:"gateway_ip,omitempty"`
+	PrefixLength              int64  `json:"prefix_length,omitempty"`
+	ExternalConnectivityState string `json:"external_connectivity_state,omitempty"`
+}
+
+type IPAssignment struct {
+
+	// Required: true
+	VMReference *Reference `json:"vm_reference"`
+
+	// Required: true
+	IPConfigList []*RecoveryIPConfig `json:"ip_config_list"`
+}
+
+type RecoveryIPConfig struct {
+	IPAddress string `json:"ip_address,omitempty"`
+}
+
+type FloatingIPAssignment struct {
+	AvailabilityZoneURL string                      `json:"availability_zone_url,omitempty"`
+	VMIPAssignmentList  []*FloatingIPVMIPAssignment `json:"vm_ip_assignment_list,omitempty"`
+}
+
+type FloatingIPVMIPAssignment struct {
+	VMReference              *Reference        `json:"vm_reference,omitempty"`
+	VMNicInformation         *VMNicInformation `json:"vm_nic_information,omitempty"`
+	RecoveryFloatingIPConfig *FloatingIPConfig `json:"recovery_floating_ip_config,omitempty"`
+	TestFloatingIPConfig     *FloatingIPConfig `json:"test_floating_ip_config,omitempty"`
+}
+
+type VMNicInformation struct {
+	IP   string `json:"ip,omitempty"`
+	UUID string `json:"uuid,omitempty"`
+}
+
+type FloatingIPConfig struct {
+	IP                        string `json:"ip,omitempty"`
+	ShouldAllocateDynamically *bool  `json:"should_allocate_dynamically,omitempty"`
+}
+
+type RecoveryPlanDefStatus struct {
+
+	// A description for the recovery plan.
+	Description string `json:"description,omitempty"`
+
+	// Any error messages for the recovery plan, if in an error state.
+	MessageList []*MessageResource `json:"message_list,omitempty"`
+
+	// name
+	Name string `json:"name,omitempty"`
+
+	// resources
+	Resources *RecoveryPlanResources `json:"resources,omitempty"`
+
+	// The state of the recovery plan.
+	State string `json:"state,omitempty"`
+
+	ExecutionContext *ExecutionContext `json:"execution_context,omitempty"`
+}
+
+type RecoveryPlanIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *RecoveryPlan `json:"spec,omitempty"`
+
+	// status
+	Status *RecoveryPlanDefStatus `json:"status,omitempty"`
+}
+
+type RecoveryPlanIntentRequest struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *RecoveryPlan `json:"spec,omitempty"`
+}
+
+type RecoveryPlanListIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// entities
+	Entities []*RecoveryPlanIntent `json:"entities"`
+
+	// metadata
+	// Required: true
+	Metadata *ListMetadata `json:"metadata"`
+}
+
+type RecoveryPlanJob struct {
+
+	// recovery plan job Name.
+	// Required: true
+	Name string `json:"name"`
+
+	// resources
+	// Required: true
+	Resources *RecoveryPlanJobResources `json:"resources"`
+}
+
+type RecoveryPlanJobResources struct {
+
+	// Required: true
+	ExecutionParameters *RecoveryPlanJobExecutionParameters `json:"execution_parameters"`
+
+	// Required: true
+	RecoveryPlanReference *Reference `json:"recovery_plan_reference"`
+}
+
+type RecoveryPlanJobExecutionParameters struct {
+
+	// One of the RecoveryPlanJobAction* constants
+	// Required: true
+	ActionType string `json:"action_type"`
+
+	// The locations the entities are recovered from
+	// Required: true
+	FailedAvailabilityZoneList []*RecoveryPlanJobAvailabilityZone `json:"failed_availability_zone_list"`
+
+	// The locations the entities are recovered to
+	// Required: true
+	RecoveryAvailabilityZoneList []*RecoveryPlanJobAvailabilityZone `json:"recovery_availability_zone_list"`
+
+	// Continue even if the validation reports errors
+	ShouldContinueOnValidationFailure *bool `json:"should_continue_on_validation_failure,omitempty"`
+}
+
+type RecoveryPlanJobAvailabilityZone struct {
+
+	// Required: true
+	AvailabilityZoneURL string `json:"availability_zone_url"`
+
+	// The clusters of the availability zone, all clusters if empty
+	ClusterReferenceList []*Reference `json:"cluster_reference_list,omitempty"`
+}
+
+type RecoveryPlanJobResourcesDefStatus struct {
+	ExecutionParameters   *RecoveryPlanJobExecutionParameters `json:"execution_parameters,omitempty"`
+	RecoveryPlanReference *Reference                          `json:"recovery_plan_reference,omitempty"`
+
+	// Progress of the job
+	ExecutionStatus *RecoveryPlanJobExecutionStatus `json:"execution_status,omitempty"`
+
+	// Errors and warnings found by the validation
+	ValidationInformation *RecoveryPlanValidationInformation `json:"validation_information,omitempty"`
+
+	// Time the job started and ended in microseconds
+	StartTime int64 `json:"start_time,omitempty"`
+	EndTime   int64 `json:"end_time,omitempty"`
+}
+
+type RecoveryPlanJobExecutionStatus struct {
+
+	// The status of the job, for example RUNNING, COMPLETED or FAILED
+	Status string `json:"status,omitempty"`
+
+	PercentageComplete int64 `json:"percentage_complete,omitempty"`
+}
+
+type RecoveryPlanValidationInformation struct {
+	ErrorsList   []*RecoveryPlanValidationMessage `json:"errors_list,omitempty"`
+	WarningsList []*RecoveryPlanValidationMessage `json:"warnings_list,omitempty"`
+}
+
+type RecoveryPlanValidationMessage struct {
+	ErrorCode              string       `json:"error_code,omitempty"`
+	CauseAndResolution     string       `json:"cause_and_resolution,omitempty"`
+	ImpactedEntityInfoList []*Reference `json:"impacted_entity_info_list,omitempty"`
+	Message                string       `json:"message,omitempty"`
+}
+
+type RecoveryPlanJobDefStatus struct {
+
+	// Any error messages for the recovery plan job, if in an error state.
+	MessageList []*MessageResource `json:"message_list,omitempty"`
+
+	// name
+	Name string `json:"name,omitempty"`
+
+	// resources
+	Resources *RecoveryPlanJobResourcesDefStatus `json:"resources,omitempty"`
+
+	// The state of the recovery plan job.
+	State string `json:"state,omitempty"`
+
+	ExecutionContext *ExecutionContext `json:"execution_context,omitempty"`
+}
+
+type RecoveryPlanJobIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *RecoveryPlanJob `json:"spec,omitempty"`
+
+	// status
+	Status *RecoveryPlanJobDefStatus `json:"status,omitempty"`
+}
+
+type RecoveryPlanJobListIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// entities
+	Entities []*RecoveryPlanJobIntent `json:"entities"`
+
+	// metadata
+	// Required: true
+	Metadata *ListMetadata `json:"metadata"`
+}
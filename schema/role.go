@@ -0,0 +1,164 @@
+package schema
+
+type Role struct {
+
+	// A description for the role.
+	// Max Length: 1000
+	Description string `json:"description,omitempty"`
+
+	// role Name.
+	// Required: true
+	// Max Length: 64
+	Name string `json:"name"`
+
+	// resources
+	// Required: true
+	Resources *RoleResources `json:"resources"`
+}
+
+type RoleResources struct {
+
+	// List of permissions granted by the role
+	PermissionReferenceList []*Reference `json:"permission_reference_list,omitempty"`
+}
+
+type RoleDefStatus struct {
+
+	// A description for the role.
+	Description string `json:"description,omitempty"`
+
+	// Any error messages for the role, if in an error state.
+	MessageList []*MessageResource `json:"message_list,omitempty"`
+
+	// name
+	Name string `json:"name,omitempty"`
+
+	// resources
+	Resources *RoleResources `json:"resources,omitempty"`
+
+	// The state of the role.
+	State string `json:"state,omitempty"`
+
+	ExecutionContext *ExecutionContext `json:"execution_context,omitempty"`
+}
+
+type RoleIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *Role `json:"spec,omitempty"`
+
+	// status
+	Status *RoleDefStatus `json:"status,omitempty"`
+}
+
+type RoleIntentRequest struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *Role `json:"spec,omitempty"`
+}
+
+type RoleListIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// entities
+	Entities []*RoleIntent `json:"entities"`
+
+	// metadata
+	// Required: true
+	Metadata *ListMetadata `json:"metadata"`
+}
+
+type Permission struct {
+
+	// A description for the permission.
+	Description string `json:"description,omitempty"`
+
+	// permission Name.
+	Name string `json:"name,omitempty"`
+
+	// resources
+	Resources *PermissionResources `json:"resources,omitempty"`
+}
+
+type PermissionResources struct {
+
+	// The operation being permitted, for example create or view
+	Operation string `json:"operation,omitempty"`
+
+	// The kind the permission applies to, for example vm
+	Kind string `json:"kind,omitempty"`
+
+	// The fields the permission applies to
+	Fields *PermissionFields `json:"fields,omitempty"`
+}
+
+type PermissionFields struct {
+
+	// Either ALLOW or DISALLOW
+	FieldMode string `json:"field_mode,omitempty"`
+
+	FieldNameList []string `json:"field_name_list,omitempty"`
+}
+
+type PermissionDefStatus struct {
+
+	// A description for the permission.
+	Description string `json:"description,omitempty"`
+
+	// Any error messages for the permission, if in an error state.
+	MessageList []*MessageResource `json:"message_list,omitempty"`
+
+	// name
+	Name string `json:"name,omitempty"`
+
+	// resources
+	Resources *PermissionResources `json:"resources,omitempty"`
+
+	// The state of the permission.
+	State string `json:"state,omitempty"`
+}
+
+type PermissionIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *Permission `json:"spec,omitempty"`
+
+	// status
+	Status *PermissionDefStatus `json:"status,omitempty"`
+}
+
+type PermissionListIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// entities
+	Entities []*PermissionIntent `json:"entities"`
+
+	// metadata
+	// Required: true
+	Metadata *ListMetadata `json:"metadata"`
+}
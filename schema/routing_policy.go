@@ -1,5 +1,23 @@
 package schema
 
+const (
+	RoutingPolicyPriorityMin = 1
+	RoutingPolicyPriorityMax = 1000
+
+	RoutingPolicyActionPermit  = "PERMIT"
+	RoutingPolicyActionDeny    = "DENY"
+	RoutingPolicyActionReroute = "REROUTE"
+
+	AddressTypeInternet = "INTERNET"
+	AddressTypeAll      = "ALL"
+
+	ProtocolTypeAll            = "ALL"
+	ProtocolTypeTCP            = "TCP"
+	ProtocolTypeUDP            = "UDP"
+	ProtocolTypeICMP           = "ICMP"
+	ProtocolTypeProtocolNumber = "PROTOCOL_NUMBER"
+)
+
 type RoutingPolicy struct {
 
 	// availability zone reference
@@ -194,6 +212,18 @@ type RoutingPolicyIntent struct {
 	Status *RoutingPolicyDefStatus `json:"status,omitempty"`
 }
 
+type RoutingPolicyIntentRequest struct {
+
+	// api version
+	// Required: true
+	APIVersion string `json:"api_version"`
+
+	Metadata *Metadata `json:"metadata,omitempty"`
+
+	// spec
+	Spec *RoutingPolicy `json:"spec,omitempty"`
+}
+
 type RoutingPolicyDefStatus struct {
 
 	// availability zone reference
@@ -218,6 +248,8 @@ type RoutingPolicyDefStatus struct {
 
 	// The state of the routing_policy.
 	State string `json:"state,omitempty"`
+
+	ExecutionContext *ExecutionContext `json:"execution_context,omitempty"`
 }
 
 type RoutingPolicyResourcesDefStatus struct {
@@ -1,5 +1,12 @@
 package schema
 
+const (
+	// SnapshotTypeCrashConsistent is a snapshot taken without quiescing the guest
+	SnapshotTypeCrashConsistent = "CRASH_CONSISTENT"
+	// SnapshotTypeApplicationConsistent is a snapshot taken after quiescing the guest applications through NGT
+	SnapshotTypeApplicationConsistent = "APPLICATION_CONSISTENT"
+)
+
 type VMSnapshotListIntent struct {
 
 	// api version
@@ -94,6 +101,8 @@ type VMSnapshotDefStatus struct {
 
 	// The state of the VM snapshot.
 	State string `json:"state,omitempty"`
+
+	ExecutionContext *ExecutionContext `json:"execution_context,omitempty"`
 }
 
 type VMSnapshotDefStatusSnapshotFileListItems struct {
@@ -88,6 +88,13 @@ type VMBootConfig struct {
 	DataSourceReference *Reference `json:"data_source_reference,omitempty"`
 }
 
+const (
+	// NGTCapabilityVSSSnapshot enables application consistent snapshots through VSS
+	NGTCapabilityVSSSnapshot = "VSS_SNAPSHOT"
+	// NGTCapabilitySelfServiceRestore enables self-service restore of files from within the guest
+	NGTCapabilitySelfServiceRestore = "SELF_SERVICE_RESTORE"
+)
+
 // NutanixGuestToolsSpec Information regarding Nutanix Guest Tools.
 type NutanixGuestToolsSpec struct {
 	State                 string            `json:"state,omitempty"`                   // Nutanix Guest Tools is enabled or not.
@@ -176,6 +183,15 @@ type VMGuestPowerStateTransitionConfig struct {
 	ShouldFailOnScriptFailure bool `json:"should_fail_on_script_failure,omitempty"`
 }
 
+const (
+	// PowerStateMechanismACPI powers off the vm through an ACPI shutdown
+	PowerStateMechanismACPI = "ACPI"
+	// PowerStateMechanismGuest powers off the vm through Nutanix Guest Tools
+	PowerStateMechanismGuest = "GUEST"
+	// PowerStateMechanismHard powers off the vm immediately
+	PowerStateMechanismHard = "HARD"
+)
+
 // VMPowerStateMechanism Indicates the mechanism guiding the VM power state transition. Currently used for the transition
 // to \"OFF\" state.
 type VMPowerStateMechanism struct {
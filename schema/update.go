@@ -0,0 +1,266 @@
+package schema
+
+import "fmt"
+
+const (
+	ipAddressTypeLearned = "LEARNED"
+	bytesPerMib          = 1024 * 1024
+)
+
+// ToUpdateMetadata returns a copy of the metadata without the read-only fields
+// which must not be sent back with a PUT request.
+func (m *Metadata) ToUpdateMetadata() *Metadata {
+	if m == nil {
+		return nil
+	}
+	metadata := *m
+	metadata.LastUpdateTime = nil
+	metadata.CreationTime = nil
+	metadata.SpecHash = ""
+	metadata.OwnerReference = nil
+	return &metadata
+}
+
+// ToUpdateRequest returns a PUT-ready request built from a GET response.
+// Learned IP addresses are dropped from the NICs, the vm itself is not modified.
+// disk_size_mib is used to resize disks. If disk_size_bytes disagrees with it, the field that
+// differs from the disk in the status wins and the request only carries disk_size_mib, rounded
+// up to the next MiB. If neither was changed, disk_size_mib is kept. An error is returned if
+// both fields were changed.
+func (v *VMIntent) ToUpdateRequest() (*VMIntentRequest, error) {
+	request := &VMIntentRequest{
+		Metadata: v.Metadata.ToUpdateMetadata(),
+	}
+	if v.APIVersion != "" {
+		apiVersion := v.APIVersion
+		request.APIVersion = &apiVersion
+	}
+	if v.Spec == nil {
+		return request, nil
+	}
+
+	spec := *v.Spec
+	request.Spec = &spec
+	if spec.Resources == nil {
+		return request, nil
+	}
+
+	resources := *spec.Resources
+	spec.Resources = &resources
+
+	statusDisks := make(map[string]*VMDisk)
+	if v.Status != nil && v.Status.Resources != nil {
+		for _, d := range v.Status.Resources.DiskList {
+			if d.UUID != "" {
+				statusDisks[d.UUID] = d
+			}
+		}
+	}
+
+	resources.DiskList = make([]*VMDisk, 0, len(v.Spec.Resources.DiskList))
+	for _, d := range v.Spec.Resources.DiskList {
+		disk := *d
+		// disk_size_bytes rounded up to MiB must match disk_size_mib
+		bytesAsMib := (disk.DiskSizeBytes + bytesPerMib - 1) / bytesPerMib
+		if disk.DiskSizeMib > 0 && disk.DiskSizeBytes > 0 && bytesAsMib != disk.DiskSizeMib {
+			current, ok := statusDisks[disk.UUID]
+			bytesChanged := !ok || disk.DiskSizeBytes != current.DiskSizeBytes
+			mibChanged := !ok || disk.DiskSizeMib != current.DiskSizeMib
+			switch {
+			case bytesChanged && !mibChanged:
+				disk.DiskSizeMib = bytesAsMib
+			case !bytesChanged:
+				// disk_size_mib was changed, or the disk is sent back unchanged
+			default:
+				return nil, fmt.Errorf("disk %s: disk_size_bytes %d and disk_size_mib %d disagree", disk.UUID, disk.DiskSizeBytes, disk.DiskSizeMib)
+			}
+			disk.DiskSizeBytes = 0
+		}
+		resources.DiskList = append(resources.DiskList, &disk)
+	}
+
+	resources.NicList = make([]*VMNic, 0, len(v.Spec.Resources.NicList))
+	for _, n := range v.Spec.Resources.NicList {
+		nic := *n
+		nic.IPEndpointList = make([]*IPAddress, 0, len(n.IPEndpointList))
+		for _, ip := range n.IPEndpointList {
+			if ip.Type == ipAddressTypeLearned {
+				continue
+			}
+			nic.IPEndpointList = append(nic.IPEndpointList, ip)
+		}
+		resources.NicList = append(resources.NicList, &nic)
+	}
+
+	return request, nil
+}
+
+// ToUpdateRequest returns a PUT-ready request built from a GET response.
+func (s *SubnetIntent) ToUpdateRequest() *SubnetIntentRequest {
+	return &SubnetIntentRequest{
+		APIVersion: s.APIVersion,
+		Metadata:   s.Metadata.ToUpdateMetadata(),
+		Spec:       s.Spec,
+	}
+}
+
+// ToUpdateRequest returns a PUT-ready request built from a GET response.
+func (p *ProjectIntent) ToUpdateRequest() *ProjectIntentRequest {
+	request := &ProjectIntentRequest{
+		Metadata: p.Metadata.ToUpdateMetadata(),
+		Spec:     p.Spec,
+	}
+	if p.APIVersion != "" {
+		apiVersion := p.APIVersion
+		request.APIVersion = &apiVersion
+	}
+	return request
+}
+
+// ToUpdateRequest returns a PUT-ready request built from a GET response.
+func (i *ImageIntent) ToUpdateRequest() *ImageIntentRequest {
+	request := &ImageIntentRequest{
+		Metadata: i.Metadata.ToUpdateMetadata(),
+		Spec:     i.Spec,
+	}
+	if i.APIVersion != "" {
+		apiVersion := i.APIVersion
+		request.APIVersion = &apiVersion
+	}
+	return request
+}
+
+// ToUpdateRequest returns a PUT-ready request built from a GET response.
+func (v *VpcIntent) ToUpdateRequest() *VpcIntentRequest {
+	return &VpcIntentRequest{
+		APIVersion: v.APIVersion,
+		Metadata:   v.Metadata.ToUpdateMetadata(),
+		Spec:       v.Spec,
+	}
+}
+
+// ToUpdateRequest returns a PUT-ready request built from a GET response.
+func (f *FloatingIPIntent) ToUpdateRequest() *FloatingIPIntentRequest {
+	return &FloatingIPIntentRequest{
+		APIVersion: f.APIVersion,
+		Metadata:   f.Metadata.ToUpdateMetadata(),
+		Spec:       f.Spec,
+	}
+}
+
+// ToUpdateRequest returns a PUT-ready request built from a GET response.
+func (r *RoutingPolicyIntent) ToUpdateRequest() *RoutingPolicyIntentRequest {
+	return &RoutingPolicyIntentRequest{
+		APIVersion: r.APIVersion,
+		Metadata:   r.Metadata.ToUpdateMetadata(),
+		Spec:       r.Spec,
+	}
+}
+
+// ToUpdateRequest returns a PUT-ready request built from a GET response.
+func (u *UserIntent) ToUpdateRequest() *UserIntentRequest {
+	return &UserIntentRequest{
+		APIVersion: u.APIVersion,
+		Metadata:   u.Metadata.ToUpdateMetadata(),
+		Spec:       u.Spec,
+	}
+}
+
+// ToUpdateRequest returns a PUT-ready request built from a GET response.
+func (u *UserGroupIntent) ToUpdateRequest() *UserGroupIntentRequest {
+	return &UserGroupIntentRequest{
+		APIVersion: u.APIVersion,
+		Metadata:   u.Metadata.ToUpdateMetadata(),
+		Spec:       u.Spec,
+	}
+}
+
+// ToUpdateRequest returns a PUT-ready request built from a GET response.
+func (r *RoleIntent) ToUpdateRequest() *RoleIntentRequest {
+	return &RoleIntentRequest{
+		APIVersion: r.APIVersion,
+		Metadata:   r.Metadata.ToUpdateMetadata(),
+		Spec:       r.Spec,
+	}
+}
+
+// ToUpdateRequest returns a PUT-ready request built from a GET response.
+func (a *AccessControlPolicyIntent) ToUpdateRequest() *AccessControlPolicyIntentRequest {
+	return &AccessControlPolicyIntentRequest{
+		APIVersion: a.APIVersion,
+		Metadata:   a.Metadata.ToUpdateMetadata(),
+		Spec:       a.Spec,
+	}
+}
+
+// ToUpdateRequest returns a PUT-ready request built from a GET response.
+func (d *DirectoryServiceIntent) ToUpdateRequest() *DirectoryServiceIntentRequest {
+	return &DirectoryServiceIntentRequest{
+		APIVersion: d.APIVersion,
+		Metadata:   d.Metadata.ToUpdateMetadata(),
+		Spec:       d.Spec,
+	}
+}
+
+// ToUpdateRequest returns a PUT-ready request built from a GET response.
+func (i *IdentityProviderIntent) ToUpdateRequest() *IdentityProviderIntentRequest {
+	return &IdentityProviderIntentRequest{
+		APIVersion: i.APIVersion,
+		Metadata:   i.Metadata.ToUpdateMetadata(),
+		Spec:       i.Spec,
+	}
+}
+
+// ToUpdateRequest returns a PUT-ready request built from a GET response.
+func (p *ProtectionRuleIntent) ToUpdateRequest() *ProtectionRuleIntentRequest {
+	return &ProtectionRuleIntentRequest{
+		APIVersion: p.APIVersion,
+		Metadata:   p.Metadata.ToUpdateMetadata(),
+		Spec:       p.Spec,
+	}
+}
+
+// ToUpdateRequest returns a PUT-ready request built from a GET response.
+func (r *RecoveryPlanIntent) ToUpdateRequest() *RecoveryPlanIntentRequest {
+	return &RecoveryPlanIntentRequest{
+		APIVersion: r.APIVersion,
+		Metadata:   r.Metadata.ToUpdateMetadata(),
+		Spec:       r.Spec,
+	}
+}
+
+// ToUpdateRequest returns a PUT-ready request built from a GET response.
+func (v *VirtualNetworkIntent) ToUpdateRequest() *VirtualNetworkIntentRequest {
+	return &VirtualNetworkIntentRequest{
+		APIVersion: v.APIVersion,
+		Metadata:   v.Metadata.ToUpdateMetadata(),
+		Spec:       v.Spec,
+	}
+}
+
+// ToUpdateRequest returns a PUT-ready request built from a GET response.
+func (r *VpcRouteTableIntent) ToUpdateRequest() *VpcRouteTableIntentRequest {
+	return &VpcRouteTableIntentRequest{
+		APIVersion: r.APIVersion,
+		Metadata:   r.Metadata.ToUpdateMetadata(),
+		Spec:       r.Spec,
+	}
+}
+
+// ToUpdateRequest returns a PUT-ready request built from a GET response.
+func (g *VpnGatewayIntent) ToUpdateRequest() *VpnGatewayIntentRequest {
+	return &VpnGatewayIntentRequest{
+		APIVersion: g.APIVersion,
+		Metadata:   g.Metadata.ToUpdateMetadata(),
+		Spec:       g.Spec,
+	}
+}
+
+// ToUpdateRequest returns a PUT-ready request built from a GET response.
+func (v *VpnConnectionIntent) ToUpdateRequest() *VpnConnectionIntentRequest {
+	return &VpnConnectionIntentRequest{
+		APIVersion: v.APIVersion,
+		Metadata:   v.Metadata.ToUpdateMetadata(),
+		Spec:       v.Spec,
+	}
+}
@@ -0,0 +1,152 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	mib        = 1024 * 1024
+	unaligned  = 10*mib + 512
+	diskUUID   = "disk"
+	nicAddress = "10.0.0.10"
+)
+
+func vmWithDisk(spec, status *VMDisk) *VMIntent {
+	vm := &VMIntent{
+		Metadata: &Metadata{UUID: "vm", SpecHash: "hash"},
+		Spec: &VM{
+			Name:      "vm",
+			Resources: &VMResources{DiskList: []*VMDisk{spec}},
+		},
+		Status: &VMDefStatus{Resources: &VMResourcesDefStatus{}},
+	}
+	if status != nil {
+		vm.Status.Resources.DiskList = []*VMDisk{status}
+	}
+	return vm
+}
+
+func TestVMToUpdateRequestDiskSize(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   *VMDisk
+		status *VMDisk
+		mib    int64
+		err    string
+	}{
+		{
+			name:   "bytes changed",
+			spec:   &VMDisk{UUID: diskUUID, DiskSizeBytes: 20*mib + 1, DiskSizeMib: 10},
+			status: &VMDisk{UUID: diskUUID, DiskSizeBytes: 10 * mib, DiskSizeMib: 10},
+			mib:    21,
+		},
+		{
+			name:   "mib changed",
+			spec:   &VMDisk{UUID: diskUUID, DiskSizeBytes: 10 * mib, DiskSizeMib: 20},
+			status: &VMDisk{UUID: diskUUID, DiskSizeBytes: 10 * mib, DiskSizeMib: 10},
+			mib:    20,
+		},
+		{
+			name:   "both changed",
+			spec:   &VMDisk{UUID: diskUUID, DiskSizeBytes: 30 * mib, DiskSizeMib: 20},
+			status: &VMDisk{UUID: diskUUID, DiskSizeBytes: 10 * mib, DiskSizeMib: 10},
+			err:    "disagree",
+		},
+		{
+			name:   "unchanged and disagreeing",
+			spec:   &VMDisk{UUID: diskUUID, DiskSizeBytes: unaligned, DiskSizeMib: 10},
+			status: &VMDisk{UUID: diskUUID, DiskSizeBytes: unaligned, DiskSizeMib: 10},
+			mib:    10,
+		},
+		{
+			name:   "unchanged and agreeing",
+			spec:   &VMDisk{UUID: diskUUID, DiskSizeBytes: 10 * mib, DiskSizeMib: 10},
+			status: &VMDisk{UUID: diskUUID, DiskSizeBytes: 10 * mib, DiskSizeMib: 10},
+			mib:    10,
+		},
+		{
+			name: "new disk with mib only",
+			spec: &VMDisk{DiskSizeMib: 10},
+			mib:  10,
+		},
+		{
+			name: "new disk disagreeing",
+			spec: &VMDisk{DiskSizeBytes: 30 * mib, DiskSizeMib: 20},
+			err:  "disagree",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := *tt.spec
+			vm := vmWithDisk(tt.spec, tt.status)
+			request, err := vm.ToUpdateRequest()
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("err = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			disk := request.Spec.Resources.DiskList[0]
+			if disk.DiskSizeMib != tt.mib {
+				t.Errorf("disk_size_mib = %d, want %d", disk.DiskSizeMib, tt.mib)
+			}
+			agree := (disk.DiskSizeBytes+mib-1)/mib == disk.DiskSizeMib
+			if disk.DiskSizeBytes != 0 && !agree {
+				t.Errorf("disk_size_bytes %d sent with disk_size_mib %d", disk.DiskSizeBytes, disk.DiskSizeMib)
+			}
+			if !reflect.DeepEqual(*vm.Spec.Resources.DiskList[0], original) {
+				t.Errorf("vm disk was modified")
+			}
+		})
+	}
+}
+
+func TestVMToUpdateRequest(t *testing.T) {
+	vm := &VMIntent{
+		APIVersion: "3.1",
+		Metadata:   &Metadata{UUID: "vm", SpecHash: "hash"},
+		Spec: &VM{
+			Name: "vm",
+			Resources: &VMResources{NicList: []*VMNic{{
+				IPEndpointList: []*IPAddress{
+					{IP: nicAddress, Type: "ASSIGNED"},
+					{IP: "10.0.0.11", Type: ipAddressTypeLearned},
+				},
+			}}},
+		},
+	}
+
+	request, err := vm.ToUpdateRequest()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if request.APIVersion == nil || *request.APIVersion != "3.1" {
+		t.Errorf("api_version = %v, want 3.1", request.APIVersion)
+	}
+	if request.Metadata.SpecHash != "" {
+		t.Errorf("spec_hash was not dropped")
+	}
+	endpoints := request.Spec.Resources.NicList[0].IPEndpointList
+	if len(endpoints) != 1 || endpoints[0].IP != nicAddress {
+		t.Errorf("ip endpoints = %v, want only %s", endpoints, nicAddress)
+	}
+	if len(vm.Spec.Resources.NicList[0].IPEndpointList) != 2 {
+		t.Errorf("learned ip was removed from the vm")
+	}
+}
+
+func TestVMToUpdateRequestWithoutSpec(t *testing.T) {
+	request, err := (&VMIntent{Metadata: &Metadata{UUID: "vm"}}).ToUpdateRequest()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if request.Spec != nil {
+		t.Errorf("spec = %v, want nil", request.Spec)
+	}
+}
@@ -0,0 +1,225 @@
+package schema
+
+type User struct {
+
+	// resources
+	// Required: true
+	Resources *UserResources `json:"resources"`
+}
+
+type UserResources struct {
+
+	// A user from a directory service
+	DirectoryServiceUser *DirectoryServiceUser `json:"directory_service_user,omitempty"`
+
+	// A user from a SAML identity provider
+	IdentityProviderUser *IdentityProviderUser `json:"identity_provider_user,omitempty"`
+}
+
+type DirectoryServiceUser struct {
+
+	// The user principal name, for example user@example.com
+	// Required: true
+	UserPrincipalName string `json:"user_principal_name"`
+
+	// directory service reference
+	DirectoryServiceReference *Reference `json:"directory_service_reference,omitempty"`
+
+	// The default user principal name, read only
+	DefaultUserPrincipalName string `json:"default_user_principal_name,omitempty"`
+}
+
+type IdentityProviderUser struct {
+
+	// The username from the identity provider
+	// Required: true
+	Username string `json:"username"`
+
+	// identity provider reference
+	IdentityProviderReference *Reference `json:"identity_provider_reference,omitempty"`
+}
+
+type UserResourcesDefStatus struct {
+	AccessControlPolicyReferenceList []*Reference `json:"access_control_policy_reference_list,omitempty"`
+
+	DirectoryServiceUser *DirectoryServiceUser `json:"directory_service_user,omitempty"`
+
+	// The display name of the user
+	DisplayName string `json:"display_name,omitempty"`
+
+	IdentityProviderUser *IdentityProviderUser `json:"identity_provider_user,omitempty"`
+
+	// The projects the user is a member of
+	ProjectsReferenceList []*Reference `json:"projects_reference_list,omitempty"`
+
+	// The type of the user, for example DIRECTORY_SERVICE or LOCAL
+	UserType string `json:"user_type,omitempty"`
+}
+
+type UserDefStatus struct {
+
+	// Any error messages for the user, if in an error state.
+	MessageList []*MessageResource `json:"message_list,omitempty"`
+
+	// name
+	Name string `json:"name,omitempty"`
+
+	// resources
+	Resources *UserResourcesDefStatus `json:"resources,omitempty"`
+
+	// The state of the user.
+	State string `json:"state,omitempty"`
+
+	ExecutionContext *ExecutionContext `json:"execution_context,omitempty"`
+}
+
+type UserIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *User `json:"spec,omitempty"`
+
+	// status
+	Status *UserDefStatus `json:"status,omitempty"`
+}
+
+type UserIntentRequest struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *User `json:"spec,omitempty"`
+}
+
+type UserListIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// entities
+	Entities []*UserIntent `json:"entities"`
+
+	// metadata
+	// Required: true
+	Metadata *ListMetadata `json:"metadata"`
+}
+
+type UserGroup struct {
+
+	// resources
+	// Required: true
+	Resources *UserGroupResources `json:"resources"`
+}
+
+type UserGroupResources struct {
+
+	// A group from a directory service
+	DirectoryServiceUserGroup *DirectoryServiceUserGroup `json:"directory_service_user_group,omitempty"`
+
+	// A group from a SAML identity provider
+	SamlUserGroup *SamlUserGroup `json:"saml_user_group,omitempty"`
+}
+
+type DirectoryServiceUserGroup struct {
+
+	// The distinguished name of the group, for example cn=admins,dc=example,dc=com
+	// Required: true
+	DistinguishedName string `json:"distinguished_name"`
+
+	// directory service reference
+	DirectoryServiceReference *Reference `json:"directory_service_reference,omitempty"`
+}
+
+type SamlUserGroup struct {
+
+	// The name of the group
+	// Required: true
+	Name string `json:"name"`
+
+	// The uuid of the identity provider
+	IdpUUID string `json:"idp_uuid,omitempty"`
+}
+
+type UserGroupResourcesDefStatus struct {
+	AccessControlPolicyReferenceList []*Reference `json:"access_control_policy_reference_list,omitempty"`
+
+	DirectoryServiceUserGroup *DirectoryServiceUserGroup `json:"directory_service_user_group,omitempty"`
+
+	// The display name of the group
+	DisplayName string `json:"display_name,omitempty"`
+
+	// The projects the group is a member of
+	ProjectsReferenceList []*Reference `json:"projects_reference_list,omitempty"`
+
+	SamlUserGroup *SamlUserGroup `json:"saml_user_group,omitempty"`
+
+	// The type of the group, for example DIRECTORY_SERVICE or SAML
+	UserGroupType string `json:"user_group_type,omitempty"`
+}
+
+type UserGroupDefStatus struct {
+
+	// Any error messages for the user group, if in an error state.
+	MessageList []*MessageResource `json:"message_list,omitempty"`
+
+	// resources
+	Resources *UserGroupResourcesDefStatus `json:"resources,omitempty"`
+
+	// The state of the user group.
+	State string `json:"state,omitempty"`
+
+	ExecutionContext *ExecutionContext `json:"execution_context,omitempty"`
+}
+
+type UserGroupIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *UserGroup `json:"spec,omitempty"`
+
+	// status
+	Status *UserGroupDefStatus `json:"status,omitempty"`
+}
+
+type UserGroupIntentRequest struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *UserGroup `json:"spec,omitempty"`
+}
+
+type UserGroupListIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// entities
+	Entities []*UserGroupIntent `json:"entities"`
+
+	// metadata
+	// Required: true
+	Metadata *ListMetadata `json:"metadata"`
+}
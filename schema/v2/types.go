@@ -51,6 +51,34 @@ type Task struct {
 	TaskUUID string `json:"task_uuid,omitempty"`
 }
 
+const (
+	TaskProgressQueued    = "Queued"
+	TaskProgressRunning   = "Running"
+	TaskProgressSucceeded = "Succeeded"
+	TaskProgressFailed    = "Failed"
+	TaskProgressAborted   = "Aborted"
+)
+
+// TaskStatus is a task of the v2 Prism Element task API
+type TaskStatus struct {
+	UUID               string        `json:"uuid,omitempty"`
+	OperationType      string        `json:"operation_type,omitempty"`
+	ProgressStatus     string        `json:"progress_status,omitempty"`
+	PercentageComplete int64         `json:"percentage_complete,omitempty"`
+	EntityList         []*TaskEntity `json:"entity_list,omitempty"`
+	MetaResponse       *MetaResponse `json:"meta_response,omitempty"`
+}
+
+type TaskEntity struct {
+	EntityID   string `json:"entity_id,omitempty"`
+	EntityType string `json:"entity_type,omitempty"`
+}
+
+type MetaResponse struct {
+	ErrorCode   int64  `json:"error_code,omitempty"`
+	ErrorDetail string `json:"error_detail,omitempty"`
+}
+
 type SnapshotList struct {
 	Metadata *Metadata       `json:"metadata,omitempty"`
 	Entities []*SnapshotSpec `json:"entities,omitempty"`
@@ -95,6 +123,26 @@ type VMPowerStateCreate struct {
 	VMLogicalTimeStamp *time.Time  `json:"vm_logical_timestamp,omitempty"`
 }
 
+type Host struct {
+	UUID                  string `json:"uuid,omitempty"`
+	Name                  string `json:"name,omitempty"`
+	ClusterUUID           string `json:"cluster_uuid,omitempty"`
+	State                 string `json:"state,omitempty"`
+	HypervisorState       string `json:"hypervisor_state,omitempty"`
+	HostInMaintenanceMode *bool  `json:"host_in_maintenance_mode,omitempty"`
+	MetadataStoreStatus   string `json:"metadata_store_status,omitempty"`
+	ServiceVMExternalIP   string `json:"service_vmexternal_ip,omitempty"`
+}
+
+type HostMaintenanceMode struct {
+	EvacuateVMs bool `json:"evacuate_vms"`
+}
+
+type VMMigrate struct {
+	HostUUID string `json:"host_uuid,omitempty"`
+	Live     bool   `json:"live"`
+}
+
 type VMDiskList struct {
 	UUID    *string         `json:"uuid,omitempty"`
 	VMDisks []*SnapshotSpec `json:"vm_disks,omitempty"`
@@ -109,6 +157,24 @@ type VMDisk struct {
 	VMDiskClone       *VMDiskClone   `json:"vm_disk_clone,omitempty"`
 	VMDiskCreate      *VMDiskCreate  `json:"vm_disk_create,omitempty"`
 }
+type VMCreate struct {
+	Name            string    `json:"name"`
+	Description     string    `json:"description,omitempty"`
+	MemoryMB        int64     `json:"memory_mb,omitempty"`
+	NumVcpus        int64     `json:"num_vcpus,omitempty"`
+	NumCoresPerVcpu int64     `json:"num_cores_per_vcpu,omitempty"`
+	VMDisks         []*VMDisk `json:"vm_disks,omitempty"`
+	VMNics          []*VMNic  `json:"vm_nics,omitempty"`
+}
+
+type VMNic struct {
+	NetworkUUID        string `json:"network_uuid,omitempty"`
+	MacAddress         string `json:"mac_address,omitempty"`
+	Model              string `json:"model,omitempty"`
+	RequestedIPAddress string `json:"requested_ip_address,omitempty"`
+	IsConnected        *bool  `json:"is_connected,omitempty"`
+}
+
 type VMDiskCreate struct {
 	Size                 *int64  `json:"size,omitempty"`
 	StorageContainerUUID *string `json:"storage_container_uuid,omitempty"`
@@ -129,6 +195,73 @@ type VMDiskAddress struct {
 	VolumeGroupUUID *string `json:"volume_group_uuid,omitempty"`
 }
 
+type StatsResponse struct {
+	StatsSpecificResponses []*MetricStats `json:"stats_specific_responses,omitempty"`
+}
+
+type MetricStats struct {
+	Successful       bool    `json:"successful"`
+	Message          *string `json:"message,omitempty"`
+	StartTimeInUsecs int64   `json:"start_time_in_usecs,omitempty"`
+	IntervalInSecs   int64   `json:"interval_in_secs,omitempty"`
+	Metric           string  `json:"metric,omitempty"`
+	Values           []int64 `json:"values,omitempty"`
+}
+
+// DataPoint is a single sample of a time series
+type DataPoint struct {
+	Time  time.Time
+	Value int64
+}
+
+// TimeSeries are the samples of a metric, oldest first
+type TimeSeries struct {
+	Metric   string
+	Interval time.Duration
+	Points   []DataPoint
+}
+
+// TimeSeries converts the raw values to a time series. Samples without data, which the API
+// reports as -1, are dropped.
+func (m *MetricStats) TimeSeries() *TimeSeries {
+	series := &TimeSeries{
+		Metric:   m.Metric,
+		Interval: time.Duration(m.IntervalInSecs) * time.Second,
+		Points:   make([]DataPoint, 0, len(m.Values)),
+	}
+	start := time.Unix(0, m.StartTimeInUsecs*int64(time.Microsecond))
+	for i, value := range m.Values {
+		if value < 0 {
+			continue
+		}
+		series.Points = append(series.Points, DataPoint{
+			Time:  start.Add(time.Duration(i) * series.Interval),
+			Value: value,
+		})
+	}
+	return series
+}
+
+// Last returns the most recent sample, false if the series is empty
+func (t *TimeSeries) Last() (DataPoint, bool) {
+	if t == nil || len(t.Points) == 0 {
+		return DataPoint{}, false
+	}
+	return t.Points[len(t.Points)-1], true
+}
+
+// Average returns the mean value of the series
+func (t *TimeSeries) Average() float64 {
+	if t == nil || len(t.Points) == 0 {
+		return 0
+	}
+	var sum int64
+	for _, p := range t.Points {
+		sum += p.Value
+	}
+	return float64(sum) / float64(len(t.Points))
+}
+
 type jsonTime time.Time
 
 func (t jsonTime) MarshalJSON() ([]byte, error) {
@@ -152,3 +285,93 @@ func (t *jsonTime) UnmarshalJSON(s []byte) (err error) {
 func (t jsonTime) String() string {
 	return time.Time(t).String()
 }
+
+const (
+	ErasureCodeOn  = "on"
+	ErasureCodeOff = "off"
+
+	FingerPrintOnWriteOn  = "on"
+	FingerPrintOnWriteOff = "off"
+
+	OnDiskDedupOff         = "OFF"
+	OnDiskDedupPostProcess = "POST_PROCESS"
+)
+
+type StorageContainerList struct {
+	Metadata *Metadata           `json:"metadata,omitempty"`
+	Entities []*StorageContainer `json:"entities,omitempty"`
+}
+
+type StorageContainer struct {
+	ID                   string `json:"id,omitempty"`
+	StorageContainerUUID string `json:"storage_container_uuid,omitempty"`
+	Name                 string `json:"name,omitempty"`
+	ClusterUUID          string `json:"cluster_uuid,omitempty"`
+	StoragePoolUUID      string `json:"storage_pool_uuid,omitempty"`
+	MarkedForRemoval     bool   `json:"marked_for_removal,omitempty"`
+
+	// Capacities are in bytes. The advertised capacity limits the usable capacity of the
+	// container, the explicit reservation guarantees capacity to it.
+	MaxCapacity                   int64  `json:"max_capacity,omitempty"`
+	TotalExplicitReservedCapacity *int64 `json:"total_explicit_reserved_capacity,omitempty"`
+	TotalImplicitReservedCapacity int64  `json:"total_implicit_reserved_capacity,omitempty"`
+	AdvertisedCapacity            *int64 `json:"advertised_capacity,omitempty"`
+
+	ReplicationFactor      int64    `json:"replication_factor,omitempty"`
+	OplogReplicationFactor int64    `json:"oplog_replication_factor,omitempty"`
+	NfsWhitelist           []string `json:"nfs_whitelist,omitempty"`
+	NfsWhitelistInherited  *bool    `json:"nfs_whitelist_inherited,omitempty"`
+
+	// ErasureCode is one of the ErasureCode* constants
+	ErasureCode               string `json:"erasure_code,omitempty"`
+	ErasureCodeDelaySecs      *int64 `json:"erasure_code_delay_secs,omitempty"`
+	PreferHigherECFaultDomain *bool  `json:"prefer_higher_ecfault_domain,omitempty"`
+
+	// FingerPrintOnWrite is one of the FingerPrintOnWrite* constants, OnDiskDedup one of the OnDiskDedup* constants
+	FingerPrintOnWrite string `json:"finger_print_on_write,omitempty"`
+	OnDiskDedup        string `json:"on_disk_dedup,omitempty"`
+
+	CompressionEnabled     *bool  `json:"compression_enabled,omitempty"`
+	CompressionDelayInSecs *int64 `json:"compression_delay_in_secs,omitempty"`
+
+	IsNutanixManaged         *bool `json:"is_nutanix_managed,omitempty"`
+	EnableSoftwareEncryption *bool `json:"enable_software_encryption,omitempty"`
+
+	UsageStats map[string]string `json:"usage_stats,omitempty"`
+}
+
+// StorageContainerUsage is the capacity usage of a storage container in bytes
+type StorageContainerUsage struct {
+	CapacityBytes         int64
+	UsageBytes            int64
+	FreeBytes             int64
+	ReservedCapacityBytes int64
+	ReservedUsageBytes    int64
+	UnreservedFreeBytes   int64
+
+	// SavingRatioPPM is the data reduction ratio of compression, dedup and erasure coding in
+	// parts per million
+	SavingRatioPPM int64
+}
+
+// Usage parses the usage stats of the container. Missing stats are zero.
+func (s *StorageContainer) Usage() *StorageContainerUsage {
+	stat := func(name string) int64 {
+		value, _ := strconv.ParseInt(s.UsageStats[name], 10, 64)
+		return value
+	}
+	return &StorageContainerUsage{
+		CapacityBytes:         stat("storage.capacity_bytes"),
+		UsageBytes:            stat("storage.usage_bytes"),
+		FreeBytes:             stat("storage.free_bytes"),
+		ReservedCapacityBytes: stat("storage.reserved_capacity_bytes"),
+		ReservedUsageBytes:    stat("storage.reserved_usage_bytes"),
+		UnreservedFreeBytes:   stat("storage.user_unreserved_free_bytes"),
+		SavingRatioPPM:        stat("data_reduction.saving_ratio_ppm"),
+	}
+}
+
+// Value is the response of v2 operations that do not return an entity or a task
+type Value struct {
+	Value bool `json:"value"`
+}
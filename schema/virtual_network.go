@@ -30,6 +30,19 @@ type VirtualNetworkIntent struct {
 	Status *VirtualNetworkDefStatus `json:"status,omitempty"`
 }
 
+type VirtualNetworkIntentRequest struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata,omitempty"`
+
+	// spec
+	Spec *VirtualNetwork `json:"spec,omitempty"`
+}
+
 type VirtualNetwork struct {
 
 	// description
@@ -76,6 +89,8 @@ type VirtualNetworkDefStatus struct {
 
 	// The state of the virtual network.
 	State string `json:"state,omitempty"`
+
+	ExecutionContext *ExecutionContext `json:"execution_context,omitempty"`
 }
 
 type VirtualNetworkResourcesDefStatus struct {
@@ -4,6 +4,13 @@ import (
 	"time"
 )
 
+const (
+	// RecoveryPointTypeCrashConsistent is a recovery point taken without quiescing the guest
+	RecoveryPointTypeCrashConsistent = "CRASH_CONSISTENT"
+	// RecoveryPointTypeApplicationConsistent is a recovery point taken after quiescing the guest applications through NGT
+	RecoveryPointTypeApplicationConsistent = "APPLICATION_CONSISTENT"
+)
+
 type VMRecoveryPointListIntent struct {
 	APIVersion *string `json:"api_version"`
 
@@ -84,6 +91,8 @@ type VMRecoveryPointDefStatus struct {
 
 	// The state of the vm recovery point.
 	State string `json:"state,omitempty"`
+
+	ExecutionContext *ExecutionContext `json:"execution_context,omitempty"`
 }
 
 type VMRecoveryPointResources struct {
@@ -187,3 +196,45 @@ type VMRecoveryPointDefStatusResources struct {
 	//
 	VMSpec *VM `json:"vm_spec,omitempty"`
 }
+
+type VMRecoveryPointRestoreRequest struct {
+
+	// Overrides applied to the vm which is created from the recovery point.
+	//
+	VMOverrideResources *VMOverrideResources `json:"vm_override_resources,omitempty"`
+}
+
+// VMRecoveryPointReplicateRequest replicates a recovery point to another availability zone
+type VMRecoveryPointReplicateRequest struct {
+
+	// Reference to the availability zone the recovery point is replicated to.
+	//
+	TargetAvailabilityZoneReference *Reference `json:"target_availability_zone_reference,omitempty"`
+
+	// Reference to the cluster the recovery point is replicated to.
+	//
+	TargetClusterReference *Reference `json:"target_cluster_reference,omitempty"`
+}
+
+type VMOverrideResources struct {
+
+	// Name of the restored vm.
+	Name string `json:"name,omitempty"`
+
+	// NICs of the restored vm. If not set, the NICs of the recovery point are used.
+	NicList []*VMOverrideNic `json:"nic_list,omitempty"`
+}
+
+type VMOverrideNic struct {
+
+	// Whether the NIC is connected. Unlike VMNic the value is always sent.
+	IsConnected bool `json:"is_connected"`
+
+	// The model of this NIC.
+	Model string `json:"model,omitempty"`
+
+	// The type of this NIC. Defaults to NORMAL_NIC.
+	NicType string `json:"nic_type,omitempty"`
+
+	SubnetReference *Reference `json:"subnet_reference,omitempty"`
+}
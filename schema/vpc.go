@@ -96,6 +96,19 @@ type VpcIntent struct {
 	Status *VpcDefStatus `json:"status,omitempty"`
 }
 
+type VpcIntentRequest struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata,omitempty"`
+
+	// spec
+	Spec *Vpc `json:"spec,omitempty"`
+}
+
 type VpcDefStatus struct {
 
 	// description
@@ -113,6 +126,8 @@ type VpcDefStatus struct {
 
 	// The state of the VPC.
 	State string `json:"state,omitempty"`
+
+	ExecutionContext *ExecutionContext `json:"execution_context,omitempty"`
 }
 
 // VpcResourcesDefStatus VPC resources status
@@ -0,0 +1,98 @@
+package schema
+
+type VpcRouteTable struct {
+
+	// resources
+	// Required: true
+	Resources *VpcRouteTableResources `json:"resources"`
+}
+
+type VpcRouteTableResources struct {
+
+	// Static routes of the vpc
+	StaticRoutesList []*StaticRoute `json:"static_routes_list"`
+
+	// Next hop of the default route 0.0.0.0/0, usually an external subnet
+	DefaultRouteNexthop *NextHop `json:"default_route_nexthop,omitempty"`
+}
+
+type StaticRoute struct {
+
+	// The destination prefix in CIDR notation, for example 10.1.0.0/16
+	// Required: true
+	Destination string `json:"destination"`
+
+	// Required: true
+	Nexthop *NextHop `json:"nexthop"`
+}
+
+// NextHop is the target of a route. Exactly one reference is set.
+type NextHop struct {
+	ExternalSubnetReference                *Reference `json:"external_subnet_reference,omitempty"`
+	LocalSubnetReference                   *Reference `json:"local_subnet_reference,omitempty"`
+	VpnConnectionReference                 *Reference `json:"vpn_connection_reference,omitempty"`
+	DirectConnectVirtualInterfaceReference *Reference `json:"direct_connect_virtual_interface_reference,omitempty"`
+	VtepGatewayReference                   *Reference `json:"vtep_gateway_reference,omitempty"`
+
+	// The ip address of the next hop, only reported in the status
+	NexthopIPAddress string `json:"nexthop_ip_address,omitempty"`
+}
+
+type RouteStatus struct {
+	Destination string   `json:"destination,omitempty"`
+	Nexthop     *NextHop `json:"nexthop,omitempty"`
+
+	// Whether the route is used. Static routes can be overridden by routes with a higher priority.
+	IsActive bool  `json:"is_active,omitempty"`
+	Priority int64 `json:"priority,omitempty"`
+}
+
+type VpcRouteTableResourcesDefStatus struct {
+	StaticRoutesList  []*RouteStatus `json:"static_routes_list,omitempty"`
+	DynamicRoutesList []*RouteStatus `json:"dynamic_routes_list,omitempty"`
+	LocalRoutesList   []*RouteStatus `json:"local_routes_list,omitempty"`
+	DefaultRoute      *RouteStatus   `json:"default_route,omitempty"`
+}
+
+type VpcRouteTableDefStatus struct {
+
+	// Any error messages for the route table, if in an error state.
+	MessageList []*MessageResource `json:"message_list,omitempty"`
+
+	// resources
+	Resources *VpcRouteTableResourcesDefStatus `json:"resources,omitempty"`
+
+	// The state of the route table.
+	State string `json:"state,omitempty"`
+
+	ExecutionContext *ExecutionContext `json:"execution_context,omitempty"`
+}
+
+type VpcRouteTableIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *VpcRouteTable `json:"spec,omitempty"`
+
+	// status
+	Status *VpcRouteTableDefStatus `json:"status,omitempty"`
+}
+
+type VpcRouteTableIntentRequest struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *VpcRouteTable `json:"spec,omitempty"`
+}
@@ -0,0 +1,284 @@
+package schema
+
+const (
+	VpnGatewayTypeLocal  = "LOCAL"
+	VpnGatewayTypeRemote = "REMOTE"
+
+	VpnRoutingProtocolBGP    = "BGP"
+	VpnRoutingProtocolStatic = "STATIC"
+
+	VpnStatusUp   = "UP"
+	VpnStatusDown = "DOWN"
+)
+
+type VpnGateway struct {
+
+	// A description for the vpn gateway.
+	// Max Length: 1000
+	Description string `json:"description,omitempty"`
+
+	// vpn gateway Name.
+	// Required: true
+	// Max Length: 64
+	Name string `json:"name"`
+
+	// resources
+	// Required: true
+	Resources *VpnGatewayResources `json:"resources"`
+}
+
+type VpnGatewayResources struct {
+
+	// LOCAL for a gateway deployed in a vpc, REMOTE for the peer gateway
+	// Required: true
+	GatewayType string `json:"gateway_type"`
+
+	// The vpc of a local gateway
+	VpcReference *Reference `json:"vpc_reference,omitempty"`
+
+	// The public ip of a local gateway
+	FloatingIPReference *Reference `json:"floating_ip_reference,omitempty"`
+
+	// The public ip address of a remote gateway
+	// Pattern: ^(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)$
+	IPAddress string `json:"ip_address,omitempty"`
+
+	// The vendor of a remote gateway
+	VendorInfo *VpnVendorInfo `json:"vendor_info,omitempty"`
+
+	// Required: true
+	RoutingProtocolConfig *VpnRoutingProtocolConfig `json:"routing_protocol_config"`
+}
+
+type VpnVendorInfo struct {
+	VendorName      string `json:"vendor_name,omitempty"`
+	SoftwareVersion string `json:"software_version,omitempty"`
+}
+
+type VpnRoutingProtocolConfig struct {
+
+	// BGP or STATIC
+	// Required: true
+	RoutingProtocol string `json:"routing_protocol"`
+
+	// Required if the routing protocol is BGP
+	BgpConfig *VpnBgpConfig `json:"bgp_config,omitempty"`
+
+	// Prefixes reachable through the gateway if the routing protocol is STATIC
+	StaticRouteConfig *VpnStaticRouteConfig `json:"static_route_config,omitempty"`
+}
+
+type VpnBgpConfig struct {
+
+	// Required: true
+	Asn int64 `json:"asn"`
+
+	// The BGP session password
+	Password string `json:"password,omitempty"`
+}
+
+type VpnStaticRouteConfig struct {
+	PrefixList []*IPSubnet `json:"prefix_list,omitempty"`
+}
+
+type VpnGatewayResourcesDefStatus struct {
+	GatewayType           string                    `json:"gateway_type,omitempty"`
+	VpcReference          *Reference                `json:"vpc_reference,omitempty"`
+	FloatingIPReference   *Reference                `json:"floating_ip_reference,omitempty"`
+	IPAddress             string                    `json:"ip_address,omitempty"`
+	VendorInfo            *VpnVendorInfo            `json:"vendor_info,omitempty"`
+	RoutingProtocolConfig *VpnRoutingProtocolConfig `json:"routing_protocol_config,omitempty"`
+
+	// The vm running a local gateway
+	VMReference *Reference `json:"vm_reference,omitempty"`
+
+	// UP or DOWN
+	OperationalStatus string `json:"operational_status,omitempty"`
+}
+
+type VpnGatewayDefStatus struct {
+
+	// A description for the vpn gateway.
+	Description string `json:"description,omitempty"`
+
+	// Any error messages for the vpn gateway, if in an error state.
+	MessageList []*MessageResource `json:"message_list,omitempty"`
+
+	// name
+	Name string `json:"name,omitempty"`
+
+	// resources
+	Resources *VpnGatewayResourcesDefStatus `json:"resources,omitempty"`
+
+	// The state of the vpn gateway.
+	State string `json:"state,omitempty"`
+
+	ExecutionContext *ExecutionContext `json:"execution_context,omitempty"`
+}
+
+type VpnGatewayIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *VpnGateway `json:"spec,omitempty"`
+
+	// status
+	Status *VpnGatewayDefStatus `json:"status,omitempty"`
+}
+
+type VpnGatewayIntentRequest struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *VpnGateway `json:"spec,omitempty"`
+}
+
+type VpnGatewayListIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// entities
+	Entities []*VpnGatewayIntent `json:"entities"`
+
+	// metadata
+	// Required: true
+	Metadata *ListMetadata `json:"metadata"`
+}
+
+type VpnConnection struct {
+
+	// A description for the vpn connection.
+	// Max Length: 1000
+	Description string `json:"description,omitempty"`
+
+	// vpn connection Name.
+	// Required: true
+	// Max Length: 64
+	Name string `json:"name"`
+
+	// resources
+	// Required: true
+	Resources *VpnConnectionResources `json:"resources"`
+}
+
+type VpnConnectionResources struct {
+
+	// Required: true
+	LocalGatewayReference *Reference `json:"local_gateway_reference"`
+
+	// Required: true
+	RemoteGatewayReference *Reference `json:"remote_gateway_reference"`
+
+	// Required: true
+	IpsecConfig *IpsecConfig `json:"ipsec_config"`
+
+	// Priority of the routes learned over the connection. Routes of the connection with the
+	// highest priority are preferred if several connections learn the same prefix.
+	DynamicRoutePriority *int64 `json:"dynamic_route_priority,omitempty"`
+
+	// Whether the local gateway initiates the IPsec session
+	ShouldInitiateConnection *bool `json:"should_initiate_connection,omitempty"`
+}
+
+type IpsecConfig struct {
+
+	// Required: true
+	PreSharedKey string `json:"pre_shared_key"`
+
+	// The ip addresses of the virtual tunnel interfaces, for example 169.254.0.1/30
+	LocalVtiIP  string `json:"local_vti_ip,omitempty"`
+	RemoteVtiIP string `json:"remote_vti_ip,omitempty"`
+
+	// IKE version, IKEV1 or IKEV2
+	IkeVersion string `json:"ike_version,omitempty"`
+
+	IkeLifetimeSecs   *int64 `json:"ike_lifetime_secs,omitempty"`
+	IpsecLifetimeSecs *int64 `json:"ipsec_lifetime_secs,omitempty"`
+}
+
+type VpnConnectionResourcesDefStatus struct {
+	LocalGatewayReference    *Reference   `json:"local_gateway_reference,omitempty"`
+	RemoteGatewayReference   *Reference   `json:"remote_gateway_reference,omitempty"`
+	IpsecConfig              *IpsecConfig `json:"ipsec_config,omitempty"`
+	DynamicRoutePriority     *int64       `json:"dynamic_route_priority,omitempty"`
+	ShouldInitiateConnection *bool        `json:"should_initiate_connection,omitempty"`
+
+	// Status of the IPsec tunnel and the BGP session, UP or DOWN
+	IpsecStatus      string `json:"ipsec_status,omitempty"`
+	BgpSessionStatus string `json:"bgp_session_status,omitempty"`
+}
+
+type VpnConnectionDefStatus struct {
+
+	// A description for the vpn connection.
+	Description string `json:"description,omitempty"`
+
+	// Any error messages for the vpn connection, if in an error state.
+	MessageList []*MessageResource `json:"message_list,omitempty"`
+
+	// name
+	Name string `json:"name,omitempty"`
+
+	// resources
+	Resources *VpnConnectionResourcesDefStatus `json:"resources,omitempty"`
+
+	// The state of the vpn connection.
+	State string `json:"state,omitempty"`
+
+	ExecutionContext *ExecutionContext `json:"execution_context,omitempty"`
+}
+
+type VpnConnectionIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *VpnConnection `json:"spec,omitempty"`
+
+	// status
+	Status *VpnConnectionDefStatus `json:"status,omitempty"`
+}
+
+type VpnConnectionIntentRequest struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// metadata
+	// Required: true
+	Metadata *Metadata `json:"metadata"`
+
+	// spec
+	Spec *VpnConnection `json:"spec,omitempty"`
+}
+
+type VpnConnectionListIntent struct {
+
+	// api version
+	APIVersion string `json:"api_version,omitempty"`
+
+	// entities
+	Entities []*VpnConnectionIntent `json:"entities"`
+
+	// metadata
+	// Required: true
+	Metadata *ListMetadata `json:"metadata"`
+}
@@ -155,3 +155,109 @@ func (c *SnapshotClient) Delete(ctx context.Context, vm *schema.VMIntent, snapsh
 	}
 	return response, nil
 }
+
+// RestoreAsNewVM creates a new vm from the snapshot and waits for it to be created.
+// Snapshots are local to the cluster of vm, so the new vm is always created on that cluster.
+// The vm the snapshot was taken of is not modified.
+func (c *SnapshotClient) RestoreAsNewVM(ctx context.Context, snapshot *v2.SnapshotSpec, vm *schema.VMIntent, opts *RestoreOptions) (*schema.VMIntent, error) {
+	if opts == nil || opts.Name == "" {
+		return nil, fmt.Errorf("a name for the new vm is required")
+	}
+	if vm.Spec == nil || vm.Spec.ClusterReference == nil {
+		return nil, fmt.Errorf("vm %s has no cluster reference", vm.Metadata.UUID)
+	}
+	clusterUUID := vm.Spec.ClusterReference.UUID
+	if opts.ClusterReference != nil && opts.ClusterReference.UUID != clusterUUID {
+		return nil, fmt.Errorf("snapshot %s is local to cluster %s and cannot be restored on cluster %s", snapshot.UUID, clusterUUID, opts.ClusterReference.UUID)
+	}
+	if snapshot.VMCreateSpec == nil {
+		return nil, fmt.Errorf("snapshot %s has no vm create spec", snapshot.UUID)
+	}
+
+	specData, err := json.Marshal(snapshot.VMCreateSpec)
+	if err != nil {
+		return nil, err
+	}
+	source := new(v2.VMCreate)
+	if err = json.Unmarshal(specData, source); err != nil {
+		return nil, err
+	}
+
+	vmCreate := &v2.VMCreate{
+		Name:            opts.Name,
+		Description:     source.Description,
+		MemoryMB:        source.MemoryMB,
+		NumVcpus:        source.NumVcpus,
+		NumCoresPerVcpu: source.NumCoresPerVcpu,
+	}
+
+	for i, disk := range source.VMDisks {
+		if disk.DiskAddress == nil {
+			return nil, fmt.Errorf("disk %d of snapshot %s has no disk address", i, snapshot.UUID)
+		}
+		address := &v2.VMDiskAddress{
+			DevieBus:    disk.DiskAddress.DevieBus,
+			DeviceIndex: disk.DiskAddress.DeviceIndex,
+		}
+		if utils.BoolValue(disk.IsCDROM) {
+			vmCreate.VMDisks = append(vmCreate.VMDisks, &v2.VMDisk{
+				DiskAddress: address,
+				IsCDROM:     utils.BoolPtr(true),
+				IsEmpty:     utils.BoolPtr(true),
+			})
+			continue
+		}
+		if disk.DiskAddress.VMDiskUUID == nil {
+			return nil, fmt.Errorf("disk %d of snapshot %s has no vm disk uuid", i, snapshot.UUID)
+		}
+		vmCreate.VMDisks = append(vmCreate.VMDisks, &v2.VMDisk{
+			DiskAddress: address,
+			VMDiskClone: &v2.VMDiskClone{
+				DiskAddress:       &v2.VMDiskAddress{VMDiskUUID: disk.DiskAddress.VMDiskUUID},
+				SnapshotGroupUUID: utils.StringPtr(snapshot.GroupUUID),
+			},
+		})
+	}
+
+	for _, n := range source.VMNics {
+		nic := &v2.VMNic{
+			NetworkUUID: n.NetworkUUID,
+			Model:       n.Model,
+			IsConnected: utils.BoolPtr(!opts.DisconnectNics),
+		}
+		if opts.SubnetReference != nil {
+			nic.NetworkUUID = opts.SubnetReference.UUID
+		}
+		vmCreate.VMNics = append(vmCreate.VMNics, nic)
+	}
+
+	reqBodyData, err := json.Marshal(vmCreate)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.NewV2PERequest(ctx, http.MethodPost, clusterUUID, vmBasePath, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(v2.Task)
+	err = c.client.Do(req, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.TaskUUID == "" {
+		return nil, fmt.Errorf("restore of snapshot %s returned no task", snapshot.UUID)
+	}
+	task, err := c.client.Task.WaitV2(ctx, clusterUUID, response.TaskUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	vmUUID := entityUUIDFromV2Task(task, vm.Metadata.UUID)
+	if vmUUID == "" {
+		return nil, fmt.Errorf("restore task %s did not reference the new vm", response.TaskUUID)
+	}
+	return c.client.VM.GetByUUID(ctx, vmUUID)
+}
@@ -0,0 +1,172 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+	v2 "github.com/tecbiz-ch/nutanix-go-sdk/schema/v2"
+)
+
+const (
+	vmStatsPath          = "/vms/%s/stats/"
+	hostStatsPath        = "/hosts/%s/stats/"
+	clusterStatsPath     = "/cluster/stats/"
+	virtualDiskStatsPath = "/virtual_disks/%s/stats/"
+)
+
+// statsMetrics are the metric names of an entity type backing the typed Stats fields
+type statsMetrics struct {
+	cpu, memory, iops, latency, bandwidth string
+}
+
+var (
+	vmStatsMetrics = statsMetrics{
+		cpu:       "hypervisor_cpu_usage_ppm",
+		memory:    "memory_usage_ppm",
+		iops:      "controller_num_iops",
+		latency:   "controller_avg_io_latency_usecs",
+		bandwidth: "controller_io_bandwidth_kBps",
+	}
+	hostStatsMetrics = statsMetrics{
+		cpu:       "hypervisor_cpu_usage_ppm",
+		memory:    "hypervisor_memory_usage_ppm",
+		iops:      "num_iops",
+		latency:   "avg_io_latency_usecs",
+		bandwidth: "io_bandwidth_kBps",
+	}
+	clusterStatsMetrics = statsMetrics{
+		cpu:       "hypervisor_cpu_usage_ppm",
+		memory:    "hypervisor_memory_usage_ppm",
+		iops:      "controller_num_iops",
+		latency:   "controller_avg_io_latency_usecs",
+		bandwidth: "controller_io_bandwidth_kBps",
+	}
+	virtualDiskStatsMetrics = statsMetrics{
+		iops:      "controller_num_iops",
+		latency:   "controller_avg_io_latency_usecs",
+		bandwidth: "controller_io_bandwidth_kBps",
+	}
+)
+
+// StatsClient is a client for the v2 performance stats API.
+type StatsClient struct {
+	client *Client
+}
+
+// StatsQuery selects the metrics and the time range of a stats request
+type StatsQuery struct {
+	// Metrics are additional raw metric names, for example "hypervisor_num_received_bytes".
+	// They are returned in Stats.Series.
+	Metrics []string
+
+	// Start and End limit the time range. The API defaults to the last samples if both are zero.
+	Start time.Time
+	End   time.Time
+
+	// Interval is the sampling interval, rounded to seconds
+	Interval time.Duration
+}
+
+// Stats are the time series of an entity. Fields for metrics the entity type does not report are nil.
+type Stats struct {
+	// CPUUsagePPM is the cpu usage in parts per million
+	CPUUsagePPM *v2.TimeSeries
+
+	// MemoryUsagePPM is the memory usage in parts per million
+	MemoryUsagePPM *v2.TimeSeries
+
+	IOPS          *v2.TimeSeries
+	LatencyUsecs  *v2.TimeSeries
+	BandwidthKBps *v2.TimeSeries
+
+	// Series contains every returned metric by name
+	Series map[string]*v2.TimeSeries
+}
+
+// VM returns the stats of a vm
+func (c *StatsClient) VM(ctx context.Context, vm *schema.VMIntent, query *StatsQuery) (*Stats, error) {
+	if vm.Spec == nil || vm.Spec.ClusterReference == nil {
+		return nil, fmt.Errorf("vm %s has no cluster reference", vm.Metadata.UUID)
+	}
+	return c.get(ctx, vm.Spec.ClusterReference.UUID, fmt.Sprintf(vmStatsPath, vm.Metadata.UUID), vmStatsMetrics, query)
+}
+
+// Host returns the stats of a host
+func (c *StatsClient) Host(ctx context.Context, host *schema.HostIntent, query *StatsQuery) (*Stats, error) {
+	clusterUUID, err := hostClusterUUID(host)
+	if err != nil {
+		return nil, err
+	}
+	return c.get(ctx, clusterUUID, fmt.Sprintf(hostStatsPath, host.Metadata.UUID), hostStatsMetrics, query)
+}
+
+// Cluster returns the stats of a cluster
+func (c *StatsClient) Cluster(ctx context.Context, clusterUUID string, query *StatsQuery) (*Stats, error) {
+	return c.get(ctx, clusterUUID, clusterStatsPath, clusterStatsMetrics, query)
+}
+
+// VirtualDisk returns the stats of a virtual disk of the cluster
+func (c *StatsClient) VirtualDisk(ctx context.Context, clusterUUID, uuid string, query *StatsQuery) (*Stats, error) {
+	return c.get(ctx, clusterUUID, fmt.Sprintf(virtualDiskStatsPath, uuid), virtualDiskStatsMetrics, query)
+}
+
+func (c *StatsClient) get(ctx context.Context, clusterUUID, path string, metrics statsMetrics, query *StatsQuery) (*Stats, error) {
+	if query == nil {
+		query = &StatsQuery{}
+	}
+
+	names := make([]string, 0, 5+len(query.Metrics))
+	for _, name := range []string{metrics.cpu, metrics.memory, metrics.iops, metrics.latency, metrics.bandwidth} {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	names = append(names, query.Metrics...)
+
+	values := url.Values{}
+	values.Set("metrics", strings.Join(names, ","))
+	if !query.Start.IsZero() {
+		values.Set("start_time_in_usecs", strconv.FormatInt(query.Start.UnixNano()/int64(time.Microsecond), 10))
+	}
+	if !query.End.IsZero() {
+		values.Set("end_time_in_usecs", strconv.FormatInt(query.End.UnixNano()/int64(time.Microsecond), 10))
+	}
+	if query.Interval > 0 {
+		values.Set("interval_in_secs", strconv.FormatInt(int64(query.Interval/time.Second), 10))
+	}
+
+	req, err := c.client.NewV2PERequest(ctx, http.MethodGet, clusterUUID, path+"?"+values.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := new(v2.StatsResponse)
+	if err = c.client.Do(req, response); err != nil {
+		return nil, err
+	}
+
+	stats := &Stats{Series: make(map[string]*v2.TimeSeries, len(response.StatsSpecificResponses))}
+	for _, metric := range response.StatsSpecificResponses {
+		if !metric.Successful {
+			message := "unknown error"
+			if metric.Message != nil {
+				message = *metric.Message
+			}
+			return nil, fmt.Errorf("failed to get metric %s: %s", metric.Metric, message)
+		}
+		stats.Series[metric.Metric] = metric.TimeSeries()
+	}
+
+	stats.CPUUsagePPM = stats.Series[metrics.cpu]
+	stats.MemoryUsagePPM = stats.Series[metrics.memory]
+	stats.IOPS = stats.Series[metrics.iops]
+	stats.LatencyUsecs = stats.Series[metrics.latency]
+	stats.BandwidthKBps = stats.Series[metrics.bandwidth]
+	return stats, nil
+}
@@ -0,0 +1,245 @@
+package nutanix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/pkg/utils"
+	v2 "github.com/tecbiz-ch/nutanix-go-sdk/schema/v2"
+)
+
+const (
+	storageContainerBasePath   = "/storage_containers"
+	storageContainerSinglePath = storageContainerBasePath + "/%s"
+)
+
+// StorageContainerClient is a client for the v2 storage container API of a cluster.
+type StorageContainerClient struct {
+	client *Client
+}
+
+// Get retrieves a storage container by its UUID if the input can be parsed as an uuid, otherwise
+// it retrieves a storage container by its name
+func (c *StorageContainerClient) Get(ctx context.Context, clusterUUID, idOrName string) (*v2.StorageContainer, error) {
+	if utils.IsValidUUID(idOrName) {
+		return c.GetByUUID(ctx, clusterUUID, idOrName)
+	}
+	return c.GetByName(ctx, clusterUUID, idOrName)
+}
+
+// GetByUUID retrieves a storage container by its UUID
+func (c *StorageContainerClient) GetByUUID(ctx context.Context, clusterUUID, uuid string) (*v2.StorageContainer, error) {
+	req, err := c.client.NewV2PERequest(ctx, http.MethodGet, clusterUUID, fmt.Sprintf(storageContainerSinglePath, uuid), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	container := new(v2.StorageContainer)
+	err = c.client.Do(req, container)
+	return container, err
+}
+
+// GetByName retrieves a storage container by its name. Names are unique within a cluster.
+func (c *StorageContainerClient) GetByName(ctx context.Context, clusterUUID, name string) (*v2.StorageContainer, error) {
+	list, err := c.List(ctx, clusterUUID, &v2.Metadata{SearchString: name})
+	if err != nil {
+		return nil, err
+	}
+	for _, container := range list.Entities {
+		if container.Name == name {
+			return container, nil
+		}
+	}
+	return nil, fmt.Errorf("storage container not found: %s", name)
+}
+
+// List returns the storage containers of the cluster. Count, Page, SearchString, FilterCriteria
+// and SortCriteria of opts are passed to the API.
+func (c *StorageContainerClient) List(ctx context.Context, clusterUUID string, opts *v2.Metadata) (*v2.StorageContainerList, error) {
+	path := storageContainerBasePath
+	if opts != nil {
+		values := url.Values{}
+		if opts.Count > 0 {
+			values.Set("count", strconv.Itoa(opts.Count))
+		}
+		if opts.Page > 0 {
+			values.Set("page", strconv.Itoa(opts.Page))
+		}
+		if opts.SearchString != "" {
+			values.Set("search_string", opts.SearchString)
+		}
+		if opts.FilterCriteria != "" {
+			values.Set("filter_criteria", opts.FilterCriteria)
+		}
+		if opts.SortCriteria != "" {
+			values.Set("sort_criteria", opts.SortCriteria)
+		}
+		if len(values) > 0 {
+			path += "?" + values.Encode()
+		}
+	}
+
+	req, err := c.client.NewV2PERequest(ctx, http.MethodGet, clusterUUID, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	list := new(v2.StorageContainerList)
+	err = c.client.Do(req, list)
+	return list, err
+}
+
+// All returns all storage containers of the cluster
+func (c *StorageContainerClient) All(ctx context.Context, clusterUUID string) (*v2.StorageContainerList, error) {
+	return c.List(ctx, clusterUUID, nil)
+}
+
+// ResolveUUID returns the uuid of the storage container with the given uuid or name, for use in
+// v2.VMDiskCreate and v2.VMDiskClone
+func (c *StorageContainerClient) ResolveUUID(ctx context.Context, clusterUUID, idOrName string) (string, error) {
+	if utils.IsValidUUID(idOrName) {
+		return idOrName, nil
+	}
+	container, err := c.GetByName(ctx, clusterUUID, idOrName)
+	if err != nil {
+		return "", err
+	}
+	return container.StorageContainerUUID, nil
+}
+
+// Create creates a storage container and returns it. The v2 API does not return the created
+// container, so it is retrieved by name.
+func (c *StorageContainerClient) Create(ctx context.Context, clusterUUID string, createRequest *v2.StorageContainer) (*v2.StorageContainer, error) {
+	if err := c.send(ctx, http.MethodPost, clusterUUID, storageContainerBasePath, createRequest); err != nil {
+		return nil, err
+	}
+	return c.GetByName(ctx, clusterUUID, createRequest.Name)
+}
+
+// Update updates a storage container and returns it. StorageContainerUUID must be set.
+func (c *StorageContainerClient) Update(ctx context.Context, clusterUUID string, updateRequest *v2.StorageContainer) (*v2.StorageContainer, error) {
+	if updateRequest.StorageContainerUUID == "" {
+		return nil, fmt.Errorf("storage container uuid is required")
+	}
+	if err := c.send(ctx, http.MethodPut, clusterUUID, storageContainerBasePath, updateRequest); err != nil {
+		return nil, err
+	}
+	return c.GetByUUID(ctx, clusterUUID, updateRequest.StorageContainerUUID)
+}
+
+// UpdateWith retrieves the storage container, applies mutate and updates it
+func (c *StorageContainerClient) UpdateWith(ctx context.Context, clusterUUID, uuid string, mutate func(*v2.StorageContainer) error) (*v2.StorageContainer, error) {
+	container, err := c.GetByUUID(ctx, clusterUUID, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if err = mutate(container); err != nil {
+		return nil, err
+	}
+	// usage stats are read only
+	container.UsageStats = nil
+	return c.Update(ctx, clusterUUID, container)
+}
+
+// Delete deletes a storage container
+func (c *StorageContainerClient) Delete(ctx context.Context, clusterUUID, uuid string) error {
+	return c.send(ctx, http.MethodDelete, clusterUUID, fmt.Sprintf(storageContainerSinglePath, uuid), nil)
+}
+
+// SetCompression enables or disables inline compression, or post process compression if delay
+// is greater than zero
+func (c *StorageContainerClient) SetCompression(ctx context.Context, clusterUUID, uuid string, enabled bool, delay time.Duration) (*v2.StorageContainer, error) {
+	return c.UpdateWith(ctx, clusterUUID, uuid, func(container *v2.StorageContainer) error {
+		container.CompressionEnabled = utils.BoolPtr(enabled)
+		container.CompressionDelayInSecs = utils.Int64Ptr(int64(delay / time.Second))
+		return nil
+	})
+}
+
+// SetDeduplication enables or disables fingerprinting on write, used by cache deduplication,
+// and post process on disk deduplication. On disk deduplication requires fingerprinting.
+func (c *StorageContainerClient) SetDeduplication(ctx context.Context, clusterUUID, uuid string, fingerPrintOnWrite, onDisk bool) (*v2.StorageContainer, error) {
+	if onDisk && !fingerPrintOnWrite {
+		return nil, fmt.Errorf("on disk deduplication requires fingerprinting on write")
+	}
+	return c.UpdateWith(ctx, clusterUUID, uuid, func(container *v2.StorageContainer) error {
+		container.FingerPrintOnWrite = v2.FingerPrintOnWriteOff
+		if fingerPrintOnWrite {
+			container.FingerPrintOnWrite = v2.FingerPrintOnWriteOn
+		}
+		container.OnDiskDedup = v2.OnDiskDedupOff
+		if onDisk {
+			container.OnDiskDedup = v2.OnDiskDedupPostProcess
+		}
+		return nil
+	})
+}
+
+// SetErasureCoding enables or disables erasure coding of cold data
+func (c *StorageContainerClient) SetErasureCoding(ctx context.Context, clusterUUID, uuid string, enabled bool) (*v2.StorageContainer, error) {
+	return c.UpdateWith(ctx, clusterUUID, uuid, func(container *v2.StorageContainer) error {
+		container.ErasureCode = v2.ErasureCodeOff
+		if enabled {
+			container.ErasureCode = v2.ErasureCodeOn
+		}
+		return nil
+	})
+}
+
+// SetCapacity sets the reserved and the advertised capacity of the container in bytes. Zero
+// removes the reservation or the advertised capacity limit.
+func (c *StorageContainerClient) SetCapacity(ctx context.Context, clusterUUID, uuid string, reservedBytes, advertisedBytes int64) (*v2.StorageContainer, error) {
+	if reservedBytes < 0 || advertisedBytes < 0 {
+		return nil, fmt.Errorf("capacities must not be negative")
+	}
+	if advertisedBytes > 0 && reservedBytes > advertisedBytes {
+		return nil, fmt.Errorf("reserved capacity %d exceeds advertised capacity %d", reservedBytes, advertisedBytes)
+	}
+	return c.UpdateWith(ctx, clusterUUID, uuid, func(container *v2.StorageContainer) error {
+		container.TotalExplicitReservedCapacity = utils.Int64Ptr(reservedBytes)
+		container.AdvertisedCapacity = utils.Int64Ptr(advertisedBytes)
+		return nil
+	})
+}
+
+// Usage returns the capacity usage of a storage container
+func (c *StorageContainerClient) Usage(ctx context.Context, clusterUUID, uuid string) (*v2.StorageContainerUsage, error) {
+	container, err := c.GetByUUID(ctx, clusterUUID, uuid)
+	if err != nil {
+		return nil, err
+	}
+	return container.Usage(), nil
+}
+
+// send performs a v2 request which returns a value response and fails if the value is false
+func (c *StorageContainerClient) send(ctx context.Context, method, clusterUUID, path string, body interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		reqBodyData, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(reqBodyData)
+	}
+
+	req, err := c.client.NewV2PERequest(ctx, method, clusterUUID, path, reader)
+	if err != nil {
+		return err
+	}
+
+	response := new(v2.Value)
+	if err = c.client.Do(req, response); err != nil {
+		return err
+	}
+	if !response.Value {
+		return fmt.Errorf("storage container %s %s failed", method, path)
+	}
+	return nil
+}
@@ -62,12 +62,37 @@ func (c *SubnetClient) All(ctx context.Context) (*schema.SubnetListIntent, error
 
 // Update a subnet
 func (c *SubnetClient) Update(ctx context.Context, updateRequest *schema.SubnetIntent) (*schema.SubnetIntent, error) {
-	updateRequest.Status = nil
 	response := new(schema.SubnetIntent)
-	err := c.client.requestHelper(ctx, fmt.Sprintf(subnetSinglePath, updateRequest.Metadata.UUID), http.MethodPut, updateRequest, response)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(subnetSinglePath, updateRequest.Metadata.UUID), http.MethodPut, updateRequest.ToUpdateRequest(), response)
 	return response, err
 }
 
+// UpdateWith fetches the latest subnet, applies mutate to it and updates it. On a
+// spec_version conflict the subnet is fetched again and mutate is re-applied.
+// It returns the updated subnet and the uuid of the update task.
+func (c *SubnetClient) UpdateWith(ctx context.Context, uuid string, mutate func(*schema.SubnetIntent) error) (*schema.SubnetIntent, string, error) {
+	var response *schema.SubnetIntent
+	err := c.client.retryOnConflict(ctx, func() error {
+		subnet, err := c.GetByUUID(ctx, uuid)
+		if err != nil {
+			return err
+		}
+		if err = mutate(subnet); err != nil {
+			return err
+		}
+		response, err = c.Update(ctx, subnet)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	var taskUUID string
+	if response.Status != nil {
+		taskUUID = response.Status.ExecutionContext.GetTaskUUID()
+	}
+	return response, taskUUID, nil
+}
+
 // Create a subnet
 func (c *SubnetClient) Create(ctx context.Context, createRequest *schema.SubnetIntent) (*schema.SubnetIntent, error) {
 	response := new(schema.SubnetIntent)
@@ -4,17 +4,50 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/tecbiz-ch/nutanix-go-sdk/pkg/utils"
 	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+	v2 "github.com/tecbiz-ch/nutanix-go-sdk/schema/v2"
 )
 
 const (
 	taskBasePath   = "/tasks"
 	taskListPath   = taskBasePath + "/list"
 	taskSinglePath = taskBasePath + "/%s"
+
+	taskV2SinglePath = "/tasks/%s"
+
+	taskStatusSucceeded = "SUCCEEDED"
+	taskStatusFailed    = "FAILED"
+	taskStatusAborted   = "ABORTED"
+
+	taskPollInterval = 2 * time.Second
 )
 
+// TaskError is returned by Wait if a task did not succeed
+type TaskError struct {
+	Task *schema.Task
+}
+
+func (e *TaskError) Error() string {
+	return fmt.Sprintf("task %s %s: %s", utils.StringValue(e.Task.UUID), strings.ToLower(utils.StringValue(e.Task.Status)), utils.StringValue(e.Task.ErrorDetail))
+}
+
+// TaskV2Error is returned by WaitV2 if a Prism Element task did not succeed
+type TaskV2Error struct {
+	Task *v2.TaskStatus
+}
+
+func (e *TaskV2Error) Error() string {
+	var detail string
+	if e.Task.MetaResponse != nil {
+		detail = e.Task.MetaResponse.ErrorDetail
+	}
+	return fmt.Sprintf("task %s %s: %s", e.Task.UUID, strings.ToLower(e.Task.ProgressStatus), detail)
+}
+
 // TaskClient is a client for the subnet API.
 type TaskClient struct {
 	client *Client
@@ -73,3 +106,91 @@ func (c *TaskClient) All(ctx context.Context) (*schema.TaskListIntent, error) {
 func (c *TaskClient) Delete(ctx context.Context, s *schema.Task) error {
 	return c.client.requestHelper(ctx, fmt.Sprintf(taskSinglePath, *s.UUID), http.MethodDelete, nil, nil)
 }
+
+// Wait polls a task by its UUID until it has completed. A *TaskError is returned
+// if the task failed or was aborted. An empty uuid means the request did not start a
+// task, nil is returned without polling.
+func (c *TaskClient) Wait(ctx context.Context, uuid string) (*schema.Task, error) {
+	if uuid == "" {
+		return nil, nil
+	}
+	ticker := time.NewTicker(taskPollInterval)
+	defer ticker.Stop()
+
+	for {
+		task, err := c.GetByUUID(ctx, uuid)
+		if err != nil {
+			return nil, err
+		}
+
+		switch utils.StringValue(task.Status) {
+		case taskStatusSucceeded:
+			return task, nil
+		case taskStatusFailed, taskStatusAborted:
+			return task, &TaskError{Task: task}
+		}
+
+		select {
+		case <-ctx.Done():
+			return task, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// WaitV2 polls a task of the v2 Prism Element API of the cluster until it has completed.
+// Tasks returned by v2 requests are local to the cluster and are not polled through Prism
+// Central. A *TaskV2Error is returned if the task failed or was aborted.
+func (c *TaskClient) WaitV2(ctx context.Context, clusterUUID, uuid string) (*v2.TaskStatus, error) {
+	if uuid == "" {
+		return nil, fmt.Errorf("task uuid is required")
+	}
+	ticker := time.NewTicker(taskPollInterval)
+	defer ticker.Stop()
+
+	for {
+		req, err := c.client.NewV2PERequest(ctx, http.MethodGet, clusterUUID, fmt.Sprintf(taskV2SinglePath, uuid), nil)
+		if err != nil {
+			return nil, err
+		}
+		task := new(v2.TaskStatus)
+		if err = c.client.Do(req, task); err != nil {
+			return nil, err
+		}
+
+		switch task.ProgressStatus {
+		case v2.TaskProgressSucceeded:
+			return task, nil
+		case v2.TaskProgressFailed, v2.TaskProgressAborted:
+			return task, &TaskV2Error{Task: task}
+		}
+
+		select {
+		case <-ctx.Done():
+			return task, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// entityUUIDFromV2Task returns the id of the first vm referenced by a v2 task, skipping the
+// uuid passed as exclude
+func entityUUIDFromV2Task(task *v2.TaskStatus, exclude string) string {
+	for _, entity := range task.EntityList {
+		if strings.EqualFold(entity.EntityType, "vm") && entity.EntityID != exclude {
+			return entity.EntityID
+		}
+	}
+	return ""
+}
+
+// entityUUIDFromTask returns the uuid of the first entity of the given kind referenced by
+// the task, skipping the uuid passed as exclude
+func entityUUIDFromTask(task *schema.Task, kind, exclude string) string {
+	for _, ref := range task.EntityReferenceList {
+		if ref.Kind == kind && ref.UUID != exclude {
+			return ref.UUID
+		}
+	}
+	return ""
+}
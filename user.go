@@ -0,0 +1,107 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/pkg/utils"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+const (
+	userBasePath   = "/users"
+	userListPath   = userBasePath + "/list"
+	userSinglePath = userBasePath + "/%s"
+)
+
+// UserClient is a client for the user API.
+type UserClient struct {
+	client *Client
+}
+
+// Get retrieves a user by its UUID if the input can be parsed as an uuid, otherwise it
+// retrieves a user by its name
+func (c *UserClient) Get(ctx context.Context, idOrName string) (*schema.UserIntent, error) {
+	if utils.IsValidUUID(idOrName) {
+		return c.GetByUUID(ctx, idOrName)
+	}
+	return c.GetByName(ctx, idOrName)
+}
+
+// GetByUUID retrieves a user by its UUID
+func (c *UserClient) GetByUUID(ctx context.Context, uuid string) (*schema.UserIntent, error) {
+	response := new(schema.UserIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(userSinglePath, uuid), http.MethodGet, nil, response)
+	return response, err
+}
+
+// GetByName retrieves a user by its name
+func (c *UserClient) GetByName(ctx context.Context, name string) (*schema.UserIntent, error) {
+	list, err := c.List(ctx, &schema.DSMetadata{Filter: fmt.Sprintf("username==%s", name)})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Entities) == 0 {
+		return nil, fmt.Errorf("user not found: %s", name)
+	}
+	return list.Entities[0], err
+}
+
+// List returns a list of users
+func (c *UserClient) List(ctx context.Context, opts *schema.DSMetadata) (*schema.UserListIntent, error) {
+	response := new(schema.UserListIntent)
+	err := c.client.requestHelper(ctx, userListPath, http.MethodPost, opts, response)
+	return response, err
+
+}
+
+// All returns all users
+func (c *UserClient) All(ctx context.Context) (*schema.UserListIntent, error) {
+	return c.List(ctx, &schema.DSMetadata{Length: utils.Int64Ptr(itemsPerPage), Offset: utils.Int64Ptr(0)})
+}
+
+// Create creates a user
+func (c *UserClient) Create(ctx context.Context, createRequest *schema.UserIntent) (*schema.UserIntent, error) {
+	response := new(schema.UserIntent)
+	err := c.client.requestHelper(ctx, userBasePath, http.MethodPost, createRequest, response)
+	return response, err
+}
+
+// Update a user
+func (c *UserClient) Update(ctx context.Context, user *schema.UserIntent) (*schema.UserIntent, error) {
+	response := new(schema.UserIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(userSinglePath, user.Metadata.UUID), http.MethodPut, user.ToUpdateRequest(), response)
+	return response, err
+}
+
+// UpdateWith fetches the latest user, applies mutate to it and updates it. On a
+// spec_version conflict the user is fetched again and mutate is re-applied.
+// It returns the updated user and the uuid of the update task.
+func (c *UserClient) UpdateWith(ctx context.Context, uuid string, mutate func(*schema.UserIntent) error) (*schema.UserIntent, string, error) {
+	var response *schema.UserIntent
+	err := c.client.retryOnConflict(ctx, func() error {
+		user, err := c.GetByUUID(ctx, uuid)
+		if err != nil {
+			return err
+		}
+		if err = mutate(user); err != nil {
+			return err
+		}
+		response, err = c.Update(ctx, user)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	var taskUUID string
+	if response.Status != nil {
+		taskUUID = response.Status.ExecutionContext.GetTaskUUID()
+	}
+	return response, taskUUID, nil
+}
+
+// Delete deletes a user
+func (c *UserClient) Delete(ctx context.Context, uuid string) error {
+	return c.client.requestHelper(ctx, fmt.Sprintf(userSinglePath, uuid), http.MethodDelete, nil, nil)
+}
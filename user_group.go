@@ -0,0 +1,107 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/pkg/utils"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+const (
+	userGroupBasePath   = "/user_groups"
+	userGroupListPath   = userGroupBasePath + "/list"
+	userGroupSinglePath = userGroupBasePath + "/%s"
+)
+
+// UserGroupClient is a client for the user group API.
+type UserGroupClient struct {
+	client *Client
+}
+
+// Get retrieves a user group by its UUID if the input can be parsed as an uuid, otherwise it
+// retrieves a user group by its name
+func (c *UserGroupClient) Get(ctx context.Context, idOrName string) (*schema.UserGroupIntent, error) {
+	if utils.IsValidUUID(idOrName) {
+		return c.GetByUUID(ctx, idOrName)
+	}
+	return c.GetByName(ctx, idOrName)
+}
+
+// GetByUUID retrieves a user group by its UUID
+func (c *UserGroupClient) GetByUUID(ctx context.Context, uuid string) (*schema.UserGroupIntent, error) {
+	response := new(schema.UserGroupIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(userGroupSinglePath, uuid), http.MethodGet, nil, response)
+	return response, err
+}
+
+// GetByName retrieves a user group by its name
+func (c *UserGroupClient) GetByName(ctx context.Context, name string) (*schema.UserGroupIntent, error) {
+	list, err := c.List(ctx, &schema.DSMetadata{Filter: fmt.Sprintf("group_name==%s", name)})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Entities) == 0 {
+		return nil, fmt.Errorf("user group not found: %s", name)
+	}
+	return list.Entities[0], err
+}
+
+// List returns a list of user groups
+func (c *UserGroupClient) List(ctx context.Context, opts *schema.DSMetadata) (*schema.UserGroupListIntent, error) {
+	response := new(schema.UserGroupListIntent)
+	err := c.client.requestHelper(ctx, userGroupListPath, http.MethodPost, opts, response)
+	return response, err
+
+}
+
+// All returns all user groups
+func (c *UserGroupClient) All(ctx context.Context) (*schema.UserGroupListIntent, error) {
+	return c.List(ctx, &schema.DSMetadata{Length: utils.Int64Ptr(itemsPerPage), Offset: utils.Int64Ptr(0)})
+}
+
+// Create creates a user group
+func (c *UserGroupClient) Create(ctx context.Context, createRequest *schema.UserGroupIntent) (*schema.UserGroupIntent, error) {
+	response := new(schema.UserGroupIntent)
+	err := c.client.requestHelper(ctx, userGroupBasePath, http.MethodPost, createRequest, response)
+	return response, err
+}
+
+// Update a user group
+func (c *UserGroupClient) Update(ctx context.Context, userGroup *schema.UserGroupIntent) (*schema.UserGroupIntent, error) {
+	response := new(schema.UserGroupIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(userGroupSinglePath, userGroup.Metadata.UUID), http.MethodPut, userGroup.ToUpdateRequest(), response)
+	return response, err
+}
+
+// UpdateWith fetches the latest user group, applies mutate to it and updates it. On a
+// spec_version conflict the user group is fetched again and mutate is re-applied.
+// It returns the updated user group and the uuid of the update task.
+func (c *UserGroupClient) UpdateWith(ctx context.Context, uuid string, mutate func(*schema.UserGroupIntent) error) (*schema.UserGroupIntent, string, error) {
+	var response *schema.UserGroupIntent
+	err := c.client.retryOnConflict(ctx, func() error {
+		userGroup, err := c.GetByUUID(ctx, uuid)
+		if err != nil {
+			return err
+		}
+		if err = mutate(userGroup); err != nil {
+			return err
+		}
+		response, err = c.Update(ctx, userGroup)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	var taskUUID string
+	if response.Status != nil {
+		taskUUID = response.Status.ExecutionContext.GetTaskUUID()
+	}
+	return response, taskUUID, nil
+}
+
+// Delete deletes a user group
+func (c *UserGroupClient) Delete(ctx context.Context, uuid string) error {
+	return c.client.requestHelper(ctx, fmt.Sprintf(userGroupSinglePath, uuid), http.MethodDelete, nil, nil)
+}
@@ -0,0 +1,202 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/pkg/utils"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+const (
+	virtualNetworkBasePath   = "/virtual_networks"
+	virtualNetworkListPath   = virtualNetworkBasePath + "/list"
+	virtualNetworkSinglePath = virtualNetworkBasePath + "/%s"
+)
+
+// VirtualNetworkClient is a client for the virtual network API.
+type VirtualNetworkClient struct {
+	client *Client
+}
+
+// Get retrieves a virtual network by its UUID if the input can be parsed as an uuid, otherwise it
+// retrieves a virtual network by its name
+func (c *VirtualNetworkClient) Get(ctx context.Context, idOrName string) (*schema.VirtualNetworkIntent, error) {
+	if utils.IsValidUUID(idOrName) {
+		return c.GetByUUID(ctx, idOrName)
+	}
+	return c.GetByName(ctx, idOrName)
+}
+
+// GetByUUID retrieves a virtual network by its UUID
+func (c *VirtualNetworkClient) GetByUUID(ctx context.Context, uuid string) (*schema.VirtualNetworkIntent, error) {
+	response := new(schema.VirtualNetworkIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(virtualNetworkSinglePath, uuid), http.MethodGet, nil, response)
+	return response, err
+}
+
+// GetByName retrieves a virtual network by its name
+func (c *VirtualNetworkClient) GetByName(ctx context.Context, name string) (*schema.VirtualNetworkIntent, error) {
+	list, err := c.List(ctx, &schema.DSMetadata{Filter: fmt.Sprintf("name==%s", name)})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Entities) == 0 {
+		return nil, fmt.Errorf("virtual network not found: %s", name)
+	}
+	return list.Entities[0], err
+}
+
+// List returns a list of virtual networks
+func (c *VirtualNetworkClient) List(ctx context.Context, opts *schema.DSMetadata) (*schema.VirtualNetworkListIntent, error) {
+	response := new(schema.VirtualNetworkListIntent)
+	err := c.client.requestHelper(ctx, virtualNetworkListPath, http.MethodPost, opts, response)
+	return response, err
+
+}
+
+// All returns all virtual networks
+func (c *VirtualNetworkClient) All(ctx context.Context) (*schema.VirtualNetworkListIntent, error) {
+	return c.List(ctx, &schema.DSMetadata{Length: utils.Int64Ptr(itemsPerPage), Offset: utils.Int64Ptr(0)})
+}
+
+// Create creates a virtual network
+func (c *VirtualNetworkClient) Create(ctx context.Context, createRequest *schema.VirtualNetworkIntent) (*schema.VirtualNetworkIntent, error) {
+	response := new(schema.VirtualNetworkIntent)
+	err := c.client.requestHelper(ctx, virtualNetworkBasePath, http.MethodPost, createRequest, response)
+	return response, err
+}
+
+// Update a virtual network
+func (c *VirtualNetworkClient) Update(ctx context.Context, virtualNetwork *schema.VirtualNetworkIntent) (*schema.VirtualNetworkIntent, error) {
+	response := new(schema.VirtualNetworkIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(virtualNetworkSinglePath, virtualNetwork.Metadata.UUID), http.MethodPut, virtualNetwork.ToUpdateRequest(), response)
+	return response, err
+}
+
+// UpdateWith fetches the latest virtual network, applies mutate to it and updates it. On a
+// spec_version conflict the virtual network is fetched again and mutate is re-applied.
+// It returns the updated virtual network and the uuid of the update task.
+func (c *VirtualNetworkClient) UpdateWith(ctx context.Context, uuid string, mutate func(*schema.VirtualNetworkIntent) error) (*schema.VirtualNetworkIntent, string, error) {
+	var response *schema.VirtualNetworkIntent
+	err := c.client.retryOnConflict(ctx, func() error {
+		virtualNetwork, err := c.GetByUUID(ctx, uuid)
+		if err != nil {
+			return err
+		}
+		if err = mutate(virtualNetwork); err != nil {
+			return err
+		}
+		response, err = c.Update(ctx, virtualNetwork)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	var taskUUID string
+	if response.Status != nil {
+		taskUUID = response.Status.ExecutionContext.GetTaskUUID()
+	}
+	return response, taskUUID, nil
+}
+
+// Delete deletes a virtual network
+func (c *VirtualNetworkClient) Delete(ctx context.Context, uuid string) error {
+	return c.client.requestHelper(ctx, fmt.Sprintf(virtualNetworkSinglePath, uuid), http.MethodDelete, nil, nil)
+}
+
+// VpcReferences maps the uuids of all virtual networks to references of the vpcs replacing them.
+// A vpc replaces a virtual network if it has the same uuid, otherwise if it is the only vpc with
+// the name of the virtual network and no other virtual network has that name. Virtual networks
+// without a vpc are not part of the map. An error is returned if a name matches ambiguously.
+func (c *VirtualNetworkClient) VpcReferences(ctx context.Context) (map[string]*schema.Reference, error) {
+	references, ambiguous, err := c.vpcReferences(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(ambiguous) > 0 {
+		uuids := make([]string, 0, len(ambiguous))
+		for uuid := range ambiguous {
+			uuids = append(uuids, uuid)
+		}
+		sort.Strings(uuids)
+		return nil, fmt.Errorf("virtual networks %s match more than one vpc or virtual network by name", strings.Join(uuids, ", "))
+	}
+	return references, nil
+}
+
+// vpcReferences maps the virtual networks like VpcReferences and returns the names of the
+// virtual networks matching ambiguously by their uuid
+func (c *VirtualNetworkClient) vpcReferences(ctx context.Context) (map[string]*schema.Reference, map[string]string, error) {
+	virtualNetworks, err := c.All(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	vpcs, err := c.client.VPC.All(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byUUID := make(map[string]*schema.Reference, len(vpcs.Entities))
+	byName := make(map[string][]*schema.Reference, len(vpcs.Entities))
+	for _, vpc := range vpcs.Entities {
+		ref := &schema.Reference{Kind: "vpc", UUID: vpc.Metadata.UUID}
+		if vpc.Spec != nil {
+			ref.Name = vpc.Spec.Name
+			byName[vpc.Spec.Name] = append(byName[vpc.Spec.Name], ref)
+		}
+		byUUID[vpc.Metadata.UUID] = ref
+	}
+
+	// virtual networks without a vpc of the same uuid, by name
+	unmatched := make(map[string]int)
+	for _, virtualNetwork := range virtualNetworks.Entities {
+		if _, ok := byUUID[virtualNetwork.Metadata.UUID]; !ok && virtualNetwork.Spec != nil {
+			unmatched[virtualNetwork.Spec.Name]++
+		}
+	}
+
+	references := make(map[string]*schema.Reference, len(virtualNetworks.Entities))
+	ambiguous := make(map[string]string)
+	for _, virtualNetwork := range virtualNetworks.Entities {
+		if ref, ok := byUUID[virtualNetwork.Metadata.UUID]; ok {
+			references[virtualNetwork.Metadata.UUID] = ref
+			continue
+		}
+		if virtualNetwork.Spec == nil {
+			continue
+		}
+		name := virtualNetwork.Spec.Name
+		switch refs := byName[name]; {
+		case len(refs) == 0:
+		case len(refs) > 1 || unmatched[name] > 1:
+			ambiguous[virtualNetwork.Metadata.UUID] = name
+		default:
+			references[virtualNetwork.Metadata.UUID] = refs[0]
+		}
+	}
+	return references, ambiguous, nil
+}
+
+// VpcReference maps a legacy virtual network reference to the reference of the vpc replacing it.
+// Vpc references are returned unchanged.
+func (c *VirtualNetworkClient) VpcReference(ctx context.Context, ref *schema.Reference) (*schema.Reference, error) {
+	if ref.Kind == "vpc" {
+		return ref, nil
+	}
+	references, ambiguous, err := c.vpcReferences(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if name, ok := ambiguous[ref.UUID]; ok {
+		return nil, fmt.Errorf("virtual network %s: name %q matches more than one vpc or virtual network", ref.UUID, name)
+	}
+	vpcRef, ok := references[ref.UUID]
+	if !ok {
+		return nil, fmt.Errorf("no vpc found for virtual network %s", ref.UUID)
+	}
+	return vpcRef, nil
+}
@@ -20,7 +20,10 @@ const (
 	vmRevertPath     = vmSinglePath + "/revert"
 	vmPowerStatePath = vmSinglePath + "/set_power_state"
 	vmSnapshotPath   = vmSinglePath + "/snapshot"
+	vmMigratePath    = vmSinglePath + "/migrate"
 	vmDiskPath       = "/virtual_disks?search_string=%s"
+
+	vmHostFilter = "host_uuid==%s"
 )
 
 // VMClient is a client for the VM API.
@@ -123,6 +126,40 @@ func (c *VMClient) All(ctx context.Context) (*schema.VMListIntent, error) {
 	return c.List(ctx, &schema.DSMetadata{Length: utils.Int64Ptr(itemsPerPage), Offset: utils.Int64Ptr(0)})
 }
 
+// ListAll pages through all vms matching the filter in FIQL syntax, all vms if filter is empty
+func (c *VMClient) ListAll(ctx context.Context, filter string) ([]*schema.VMIntent, error) {
+	var vms []*schema.VMIntent
+	var offset int64
+	for {
+		list, err := c.List(ctx, &schema.DSMetadata{Filter: filter, Length: utils.Int64Ptr(itemsPerPage), Offset: utils.Int64Ptr(offset)})
+		if err != nil {
+			return nil, err
+		}
+		vms = append(vms, list.Entities...)
+		offset += int64(len(list.Entities))
+		if len(list.Entities) == 0 || list.Metadata == nil || offset >= list.Metadata.TotalMatches {
+			return vms, nil
+		}
+	}
+}
+
+// listByHost returns all vms currently running on the host. The vms are filtered on the
+// host by the API, the host reference in the status of each vm is verified again.
+func (c *VMClient) listByHost(ctx context.Context, hostUUID string) ([]*schema.VMIntent, error) {
+	vms, err := c.ListAll(ctx, fmt.Sprintf(vmHostFilter, hostUUID))
+	if err != nil {
+		return nil, err
+	}
+	var onHost []*schema.VMIntent
+	for _, vm := range vms {
+		if vm.Status != nil && vm.Status.Resources != nil && vm.Status.Resources.HostReference != nil &&
+			vm.Status.Resources.HostReference.UUID == hostUUID {
+			onHost = append(onHost, vm)
+		}
+	}
+	return onHost, nil
+}
+
 // Create creates a vm
 func (c *VMClient) Create(ctx context.Context, createRequest *schema.VMIntent) (*schema.VMIntent, error) {
 	response := new(schema.VMIntent)
@@ -132,12 +169,41 @@ func (c *VMClient) Create(ctx context.Context, createRequest *schema.VMIntent) (
 
 // Update a vm
 func (c *VMClient) Update(ctx context.Context, updateRequest *schema.VMIntent) (*schema.VMIntent, error) {
-	updateRequest.Status = nil
+	request, err := updateRequest.ToUpdateRequest()
+	if err != nil {
+		return nil, err
+	}
 	response := new(schema.VMIntent)
-	err := c.client.requestHelper(ctx, fmt.Sprintf(vmSinglePath, updateRequest.Metadata.UUID), http.MethodPut, updateRequest, response)
+	err = c.client.requestHelper(ctx, fmt.Sprintf(vmSinglePath, updateRequest.Metadata.UUID), http.MethodPut, request, response)
 	return response, err
 }
 
+// UpdateWith fetches the latest vm, applies mutate to it and updates it. On a
+// spec_version conflict the vm is fetched again and mutate is re-applied.
+// It returns the updated vm and the uuid of the update task.
+func (c *VMClient) UpdateWith(ctx context.Context, uuid string, mutate func(*schema.VMIntent) error) (*schema.VMIntent, string, error) {
+	var response *schema.VMIntent
+	err := c.client.retryOnConflict(ctx, func() error {
+		vm, err := c.GetByUUID(ctx, uuid)
+		if err != nil {
+			return err
+		}
+		if err = mutate(vm); err != nil {
+			return err
+		}
+		response, err = c.Update(ctx, vm)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	var taskUUID string
+	if response.Status != nil {
+		taskUUID = response.Status.ExecutionContext.GetTaskUUID()
+	}
+	return response, taskUUID, nil
+}
+
 // Delete deletes a vm
 func (c *VMClient) Delete(ctx context.Context, uuid string) error {
 	return c.client.requestHelper(ctx, fmt.Sprintf(vmSinglePath, uuid), http.MethodDelete, nil, nil)
@@ -145,12 +211,7 @@ func (c *VMClient) Delete(ctx context.Context, uuid string) error {
 
 // RevertToRecoveryPoint ...
 func (c *VMClient) RevertToRecoveryPoint(ctx context.Context, vm *schema.VMIntent, vmRevertRequest *schema.VMRevertRequest) (*v2.Task, error) {
-	reqBodyData, err := json.Marshal(&vmRevertRequest)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := c.client.NewV3PERequest(ctx, http.MethodPost, vm.Spec.ClusterReference.UUID, fmt.Sprintf(vmRevertPath, vm.Metadata.UUID), bytes.NewReader(reqBodyData))
+	req, err := c.client.NewV3PERequest(ctx, http.MethodPost, vm.Spec.ClusterReference.UUID, fmt.Sprintf(vmRevertPath, vm.Metadata.UUID), vmRevertRequest)
 
 	if err != nil {
 		return nil, err
@@ -172,6 +233,8 @@ func (c *VMClient) CreateRecoveryPoint(ctx context.Context, vm *schema.VMIntent)
 }
 
 // CreateV3Snapshot ...
+//
+// Deprecated: use VMSnapshotClient.Create, which sets the entity, name and snapshot type.
 func (c *VMClient) CreateV3Snapshot(ctx context.Context) (*schema.ExecutionContext, error) {
 	p := &schema.VMIntent{
 		Metadata: &schema.Metadata{
@@ -250,3 +313,41 @@ func (c *VMClient) Clone(ctx context.Context, sourcevm *schema.VMIntent) (*v2.Ta
 	}
 	return task, nil
 }
+
+// Migrate live migrates the vm to targetHost and waits for the migration to complete.
+// If targetHost is nil, the cluster chooses the target host.
+func (c *VMClient) Migrate(ctx context.Context, vm *schema.VMIntent, targetHost *schema.HostIntent) (*schema.VMIntent, error) {
+	if vm.Spec == nil || vm.Spec.ClusterReference == nil {
+		return nil, fmt.Errorf("vm %s has no cluster reference", vm.Metadata.UUID)
+	}
+	migrateSpec := &v2.VMMigrate{
+		Live: true,
+	}
+	if targetHost != nil {
+		migrateSpec.HostUUID = targetHost.Metadata.UUID
+	}
+
+	reqBodyData, err := json.Marshal(migrateSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.client.NewV2PERequest(ctx, http.MethodPost, vm.Spec.ClusterReference.UUID, fmt.Sprintf(vmMigratePath, vm.Metadata.UUID), bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, err
+	}
+
+	task := new(v2.Task)
+	err = c.client.Do(req, &task)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.TaskUUID == "" {
+		return nil, fmt.Errorf("migration of vm %s returned no task", vm.Metadata.UUID)
+	}
+	if _, err = c.client.Task.WaitV2(ctx, vm.Spec.ClusterReference.UUID, task.TaskUUID); err != nil {
+		return nil, err
+	}
+	return c.GetByUUID(ctx, vm.Metadata.UUID)
+}
@@ -0,0 +1,221 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tecbiz-ch/nutanix-go-sdk/pkg/utils"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+const (
+	ngtStateEnabled         = "ENABLED"
+	ngtStateDisabled        = "DISABLED"
+	ngtIsoMountStateMounted = "MOUNTED"
+	deviceTypeCDROM         = "CDROM"
+	ngtGuestOsWindows       = "windows"
+	ngtGuestOsLinux         = "linux"
+)
+
+var (
+	// ErrNGTNotReady is returned if Nutanix Guest Tools on a vm cannot take application consistent snapshots
+	ErrNGTNotReady = errors.New("nutanix guest tools not ready for application consistent snapshots")
+
+	// ErrNGTNotSupported is returned if the guest os of a vm does not support Nutanix Guest Tools
+	ErrNGTNotSupported = errors.New("nutanix guest tools not supported by the guest os")
+
+	// ErrGuestOSUnknown is returned if a vm reports neither a guest os version nor a guest os id
+	ErrGuestOSUnknown = errors.New("guest os of the vm is unknown")
+)
+
+// ngtLinuxGuestOsIDs are the prefixes of linux guest os ids not containing "linux"
+var ngtLinuxGuestOsIDs = []string{"rhel", "centos", "ubuntu", "debian", "sles", "fedora", "opensuse", "oracle", "asianux", "coreos", "vmwarephoton", "rocky"}
+
+// EnableNGT enables Nutanix Guest Tools on the vm with the given capabilities, for example
+// schema.NGTCapabilityVSSSnapshot and schema.NGTCapabilitySelfServiceRestore, and waits for the update.
+// The enabled capabilities are kept if none are given. ErrNGTNotSupported is returned if the
+// guest os reported by the vm is not supported, ErrGuestOSUnknown if the vm reports no guest os.
+func (c *VMClient) EnableNGT(ctx context.Context, vm *schema.VMIntent, capabilities ...string) (*schema.VMIntent, error) {
+	current, err := c.GetByUUID(ctx, vm.Metadata.UUID)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkNGTSupported(current); err != nil {
+		return nil, err
+	}
+	return c.updateNGT(ctx, vm, func(ngt *schema.NutanixGuestToolsSpec) error {
+		ngt.State = ngtStateEnabled
+		if len(capabilities) > 0 {
+			ngt.EnabledCapabilityList = capabilities
+		}
+		return nil
+	})
+}
+
+// DisableNGT disables Nutanix Guest Tools on the vm and waits for the update
+func (c *VMClient) DisableNGT(ctx context.Context, vm *schema.VMIntent) (*schema.VMIntent, error) {
+	return c.updateNGT(ctx, vm, func(ngt *schema.NutanixGuestToolsSpec) error {
+		ngt.State = ngtStateDisabled
+		return nil
+	})
+}
+
+// MountNGT mounts the Nutanix Guest Tools ISO on the CD-ROM of the vm and waits for the update
+func (c *VMClient) MountNGT(ctx context.Context, vm *schema.VMIntent) (*schema.VMIntent, error) {
+	if !hasCDROM(vm) {
+		return nil, fmt.Errorf("vm %s has no CD-ROM to mount the nutanix guest tools iso", vm.Metadata.UUID)
+	}
+	return c.updateNGT(ctx, vm, func(ngt *schema.NutanixGuestToolsSpec) error {
+		ngt.IsoMountState = ngtIsoMountStateMounted
+		return nil
+	})
+}
+
+// UpgradeNGT mounts the Nutanix Guest Tools ISO with the version available on the cluster.
+// The guest agent upgrades itself from the mounted ISO. Nothing is done if the installed
+// version is already the available version.
+func (c *VMClient) UpgradeNGT(ctx context.Context, vm *schema.VMIntent) (*schema.VMIntent, error) {
+	status, err := c.NGTStatus(ctx, vm)
+	if err != nil {
+		return nil, err
+	}
+	if status.AvailableVersion == "" || status.Version == status.AvailableVersion {
+		return c.GetByUUID(ctx, vm.Metadata.UUID)
+	}
+	if !hasCDROM(vm) {
+		return nil, fmt.Errorf("vm %s has no CD-ROM to mount the nutanix guest tools iso", vm.Metadata.UUID)
+	}
+	return c.updateNGT(ctx, vm, func(ngt *schema.NutanixGuestToolsSpec) error {
+		ngt.Version = status.AvailableVersion
+		ngt.IsoMountState = ngtIsoMountStateMounted
+		return nil
+	})
+}
+
+// NGTStatus returns the current Nutanix Guest Tools status of the vm, including the installed and
+// available version and whether the communication link to the CVM is active
+func (c *VMClient) NGTStatus(ctx context.Context, vm *schema.VMIntent) (*schema.NutanixGuestToolsStatus, error) {
+	vm, err := c.GetByUUID(ctx, vm.Metadata.UUID)
+	if err != nil {
+		return nil, err
+	}
+	if vm.Status == nil || vm.Status.Resources == nil || vm.Status.Resources.GuestTools == nil || vm.Status.Resources.GuestTools.NutanixGuestTools == nil {
+		return nil, fmt.Errorf("vm %s reports no nutanix guest tools status", vm.Metadata.UUID)
+	}
+	return vm.Status.Resources.GuestTools.NutanixGuestTools, nil
+}
+
+// updateNGT applies mutate to the Nutanix Guest Tools spec of the vm and waits for the update task
+func (c *VMClient) updateNGT(ctx context.Context, vm *schema.VMIntent, mutate func(*schema.NutanixGuestToolsSpec) error) (*schema.VMIntent, error) {
+	_, taskUUID, err := c.UpdateWith(ctx, vm.Metadata.UUID, func(v *schema.VMIntent) error {
+		if v.Spec == nil {
+			return fmt.Errorf("vm %s has no spec", v.Metadata.UUID)
+		}
+		if v.Spec.Resources == nil {
+			v.Spec.Resources = &schema.VMResources{}
+		}
+		if v.Spec.Resources.GuestTools == nil {
+			v.Spec.Resources.GuestTools = &schema.GuestToolsSpec{}
+		}
+		if v.Spec.Resources.GuestTools.NutanixGuestTools == nil {
+			v.Spec.Resources.GuestTools.NutanixGuestTools = &schema.NutanixGuestToolsSpec{}
+		}
+		return mutate(v.Spec.Resources.GuestTools.NutanixGuestTools)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = c.client.Task.Wait(ctx, taskUUID); err != nil {
+		return nil, err
+	}
+	return c.GetByUUID(ctx, vm.Metadata.UUID)
+}
+
+// checkNGTSupported returns an error wrapping ErrNGTNotSupported if the guest os is not a windows
+// or linux guest. The guest os is taken from the version reported by the guest agent, for example
+// "windows:64:2019", or else from the guest os id of the vm, for example "windows9Server64Guest".
+// An error wrapping ErrGuestOSUnknown is returned if the vm reports neither.
+func checkNGTSupported(vm *schema.VMIntent) error {
+	guestOS := guestOSVersion(vm)
+	family := strings.ToLower(strings.SplitN(guestOS, ":", 2)[0])
+	if guestOS == "" {
+		guestOS = guestOSID(vm)
+		family = guestOSIDFamily(guestOS)
+	}
+	if guestOS == "" {
+		return errors.Wrapf(ErrGuestOSUnknown, "vm %s", vm.Metadata.UUID)
+	}
+	if family != ngtGuestOsWindows && family != ngtGuestOsLinux {
+		return errors.Wrapf(ErrNGTNotSupported, "vm %s: guest os %s", vm.Metadata.UUID, guestOS)
+	}
+	return nil
+}
+
+// guestOSVersion returns the guest os version reported by the guest agent, empty before it reported one
+func guestOSVersion(vm *schema.VMIntent) string {
+	if vm.Status == nil || vm.Status.Resources == nil || vm.Status.Resources.GuestTools == nil || vm.Status.Resources.GuestTools.NutanixGuestTools == nil {
+		return ""
+	}
+	return vm.Status.Resources.GuestTools.NutanixGuestTools.GuestOsVersion
+}
+
+// guestOSID returns the guest os id of the vm status, or of the spec if the status has none
+func guestOSID(vm *schema.VMIntent) string {
+	if vm.Status != nil && vm.Status.Resources != nil && utils.StringValue(vm.Status.Resources.GuestOsID) != "" {
+		return utils.StringValue(vm.Status.Resources.GuestOsID)
+	}
+	if vm.Spec != nil && vm.Spec.Resources != nil {
+		return vm.Spec.Resources.GuestOsID
+	}
+	return ""
+}
+
+// guestOSIDFamily returns ngtGuestOsWindows or ngtGuestOsLinux for the guest os ids of windows and
+// linux guests, empty for other guest os ids
+func guestOSIDFamily(id string) string {
+	id = strings.ToLower(id)
+	if strings.HasPrefix(id, "win") {
+		return ngtGuestOsWindows
+	}
+	if strings.Contains(id, ngtGuestOsLinux) {
+		return ngtGuestOsLinux
+	}
+	for _, distribution := range ngtLinuxGuestOsIDs {
+		if strings.HasPrefix(id, distribution) {
+			return ngtGuestOsLinux
+		}
+	}
+	return ""
+}
+
+func hasCDROM(vm *schema.VMIntent) bool {
+	if vm.Spec == nil || vm.Spec.Resources == nil {
+		return false
+	}
+	for _, disk := range vm.Spec.Resources.DiskList {
+		if disk.DeviceProperties != nil && disk.DeviceProperties.DeviceType == deviceTypeCDROM {
+			return true
+		}
+	}
+	return false
+}
+
+// checkNGTSnapshotReady returns an error wrapping ErrNGTNotReady if the vm cannot take
+// application consistent snapshots
+func checkNGTSnapshotReady(vm *schema.VMIntent) error {
+	if vm.Status == nil || vm.Status.Resources == nil || vm.Status.Resources.GuestTools == nil || vm.Status.Resources.GuestTools.NutanixGuestTools == nil {
+		return errors.Wrapf(ErrNGTNotReady, "vm %s: not installed", vm.Metadata.UUID)
+	}
+	ngt := vm.Status.Resources.GuestTools.NutanixGuestTools
+	switch {
+	case ngt.State != ngtStateEnabled:
+		return errors.Wrapf(ErrNGTNotReady, "vm %s: state is %s", vm.Metadata.UUID, ngt.State)
+	case !ngt.VSSSnapshotCapable:
+		return errors.Wrapf(ErrNGTNotReady, "vm %s: not vss snapshot capable", vm.Metadata.UUID)
+	case !ngt.IsReachable:
+		return errors.Wrapf(ErrNGTNotReady, "vm %s: communication link is not active", vm.Metadata.UUID)
+	}
+	return nil
+}
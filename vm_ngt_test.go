@@ -0,0 +1,56 @@
+package nutanix
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/tecbiz-ch/nutanix-go-sdk/pkg/utils"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+func ngtVM(guestOSVersion, statusGuestOSID, specGuestOSID string) *schema.VMIntent {
+	vm := &schema.VMIntent{
+		Metadata: &schema.Metadata{UUID: "vm"},
+		Spec:     &schema.VM{Resources: &schema.VMResources{GuestOsID: specGuestOSID}},
+		Status:   &schema.VMDefStatus{Resources: &schema.VMResourcesDefStatus{}},
+	}
+	if statusGuestOSID != "" {
+		vm.Status.Resources.GuestOsID = utils.StringPtr(statusGuestOSID)
+	}
+	if guestOSVersion != "" {
+		vm.Status.Resources.GuestTools = &schema.GuestToolsStatus{
+			NutanixGuestTools: &schema.NutanixGuestToolsStatus{GuestOsVersion: guestOSVersion},
+		}
+	}
+	return vm
+}
+
+func TestCheckNGTSupported(t *testing.T) {
+	tests := []struct {
+		name string
+		vm   *schema.VMIntent
+		err  error
+	}{
+		{name: "windows guest os version", vm: ngtVM("windows:64:2019", "", "")},
+		{name: "linux guest os version", vm: ngtVM("linux:64:centos-7", "", "")},
+		{name: "unsupported guest os version", vm: ngtVM("freebsd:64:13", "", ""), err: ErrNGTNotSupported},
+		{name: "guest os version wins over guest os id", vm: ngtVM("linux:64:ubuntu", "darwin64Guest", "")},
+		{name: "windows guest os id", vm: ngtVM("", "windows9Server64Guest", "")},
+		{name: "linux guest os id", vm: ngtVM("", "other3xLinux64Guest", "")},
+		{name: "distribution guest os id", vm: ngtVM("", "rhel8_64Guest", "")},
+		{name: "unsupported guest os id", vm: ngtVM("", "solaris11_64Guest", ""), err: ErrNGTNotSupported},
+		{name: "spec guest os id", vm: ngtVM("", "", "ubuntu64Guest")},
+		{name: "status guest os id wins over spec", vm: ngtVM("", "darwin64Guest", "ubuntu64Guest"), err: ErrNGTNotSupported},
+		{name: "unknown guest os", vm: ngtVM("", "", ""), err: ErrGuestOSUnknown},
+		{name: "no status", vm: &schema.VMIntent{Metadata: &schema.Metadata{UUID: "vm"}}, err: ErrGuestOSUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkNGTSupported(tt.vm)
+			if errors.Cause(err) != tt.err {
+				t.Errorf("err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tecbiz-ch/nutanix-go-sdk/pkg/utils"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+const (
+	vmPowerStateOn  = "ON"
+	vmPowerStateOff = "OFF"
+)
+
+// PowerOn powers on the vm and waits until its status reports it on. A zero timeout waits
+// until ctx is done.
+func (c *VMClient) PowerOn(ctx context.Context, vm *schema.VMIntent, timeout time.Duration) (*schema.VMIntent, error) {
+	taskUUID, err := c.setPowerState(ctx, vm.Metadata.UUID, vmPowerStateOn, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.waitPowerState(ctx, vm.Metadata.UUID, vmPowerStateOn, taskUUID, timeout)
+}
+
+// Shutdown powers off the vm using the given schema.PowerStateMechanism* mechanism and waits
+// until its status reports it off. A graceful ACPI or GUEST shutdown that does not complete
+// within timeout is escalated to a hard power off once the graceful update task completed.
+// A zero timeout waits until ctx is done and never escalates. The power state mechanism of
+// the vm spec is restored afterwards.
+func (c *VMClient) Shutdown(ctx context.Context, vm *schema.VMIntent, mechanism string, timeout time.Duration) (*schema.VMIntent, error) {
+	switch mechanism {
+	case schema.PowerStateMechanismACPI, schema.PowerStateMechanismGuest, schema.PowerStateMechanismHard:
+	default:
+		return nil, fmt.Errorf("invalid power state mechanism %q", mechanism)
+	}
+
+	current, err := c.GetByUUID(ctx, vm.Metadata.UUID)
+	if err != nil {
+		return nil, err
+	}
+	var original *schema.VMPowerStateMechanism
+	if current.Spec != nil && current.Spec.Resources != nil {
+		original = current.Spec.Resources.PowerStateMechanism
+	}
+
+	response, err := c.shutdown(ctx, vm.Metadata.UUID, mechanism, timeout)
+	if original != nil && original.Mechanism == mechanism {
+		return response, err
+	}
+	if restoreErr := c.setPowerStateMechanism(ctx, vm.Metadata.UUID, original); restoreErr != nil && err == nil {
+		return nil, errors.Wrapf(restoreErr, "restoring power state mechanism of vm %s", vm.Metadata.UUID)
+	}
+	if err != nil {
+		return response, err
+	}
+	return c.GetByUUID(ctx, vm.Metadata.UUID)
+}
+
+// shutdown powers off the vm with mechanism and escalates to a hard power off if timeout is
+// reached. The graceful update task is waited for first, so the hard power off is not
+// rejected or overwritten by it.
+func (c *VMClient) shutdown(ctx context.Context, uuid, mechanism string, timeout time.Duration) (*schema.VMIntent, error) {
+	taskUUID, err := c.setPowerState(ctx, uuid, vmPowerStateOff, &schema.VMPowerStateMechanism{Mechanism: mechanism})
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.waitPowerState(ctx, uuid, vmPowerStateOff, taskUUID, timeout)
+	if err == nil || mechanism == schema.PowerStateMechanismHard || ctx.Err() != nil || !errors.Is(err, context.DeadlineExceeded) {
+		return response, err
+	}
+
+	// a failed graceful shutdown is escalated as well
+	var taskErr *TaskError
+	if _, err = c.client.Task.Wait(ctx, taskUUID); err != nil && !errors.As(err, &taskErr) {
+		return nil, err
+	}
+	taskUUID, err = c.setPowerState(ctx, uuid, vmPowerStateOff, &schema.VMPowerStateMechanism{Mechanism: schema.PowerStateMechanismHard})
+	if err != nil {
+		return nil, err
+	}
+	return c.waitPowerState(ctx, uuid, vmPowerStateOff, taskUUID, timeout)
+}
+
+// Reboot shuts the vm down like Shutdown and powers it on again
+func (c *VMClient) Reboot(ctx context.Context, vm *schema.VMIntent, mechanism string, timeout time.Duration) (*schema.VMIntent, error) {
+	if _, err := c.Shutdown(ctx, vm, mechanism, timeout); err != nil {
+		return nil, err
+	}
+	return c.PowerOn(ctx, vm, timeout)
+}
+
+// PowerCycle powers the vm off hard and powers it on again
+func (c *VMClient) PowerCycle(ctx context.Context, vm *schema.VMIntent, timeout time.Duration) (*schema.VMIntent, error) {
+	return c.Reboot(ctx, vm, schema.PowerStateMechanismHard, timeout)
+}
+
+// setPowerState sets the desired power state and, if not nil, the power state mechanism in
+// the vm spec and returns the update task
+func (c *VMClient) setPowerState(ctx context.Context, uuid, powerState string, mechanism *schema.VMPowerStateMechanism) (string, error) {
+	_, taskUUID, err := c.UpdateWith(ctx, uuid, func(vm *schema.VMIntent) error {
+		if vm.Spec == nil || vm.Spec.Resources == nil {
+			return fmt.Errorf("vm %s has no spec resources", uuid)
+		}
+		vm.Spec.Resources.PowerState = powerState
+		if mechanism != nil {
+			vm.Spec.Resources.PowerStateMechanism = mechanism
+		}
+		return nil
+	})
+	return taskUUID, err
+}
+
+// setPowerStateMechanism sets the power state mechanism in the vm spec and waits for the update
+func (c *VMClient) setPowerStateMechanism(ctx context.Context, uuid string, mechanism *schema.VMPowerStateMechanism) error {
+	_, taskUUID, err := c.UpdateWith(ctx, uuid, func(vm *schema.VMIntent) error {
+		if vm.Spec == nil || vm.Spec.Resources == nil {
+			return fmt.Errorf("vm %s has no spec resources", uuid)
+		}
+		vm.Spec.Resources.PowerStateMechanism = mechanism
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.client.Task.Wait(ctx, taskUUID)
+	return err
+}
+
+// waitPowerState waits for the update task and until the vm status reports powerState.
+// context.DeadlineExceeded is returned if timeout is reached first.
+func (c *VMClient) waitPowerState(ctx context.Context, uuid, powerState, taskUUID string, timeout time.Duration) (*schema.VMIntent, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	if _, err := c.client.Task.Wait(ctx, taskUUID); err != nil {
+		return nil, err
+	}
+
+	ticker := time.NewTicker(taskPollInterval)
+	defer ticker.Stop()
+
+	for {
+		vm, err := c.GetByUUID(ctx, uuid)
+		if err != nil {
+			return nil, err
+		}
+		if vm.Status != nil && vm.Status.Resources != nil && utils.StringValue(vm.Status.Resources.PowerState) == powerState {
+			return vm, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return vm, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -10,11 +10,30 @@ import (
 )
 
 const (
-	vmRecoveryPointBasePath   = "/vm_recovery_points"
-	vmRecoveryPointListPath   = vmRecoveryPointBasePath + "/list"
-	vmRecoveryPointSinglePath = vmRecoveryPointBasePath + "/%s"
+	vmRecoveryPointBasePath      = "/vm_recovery_points"
+	vmRecoveryPointListPath      = vmRecoveryPointBasePath + "/list"
+	vmRecoveryPointSinglePath    = vmRecoveryPointBasePath + "/%s"
+	vmRecoveryPointRestorePath   = vmRecoveryPointSinglePath + "/restore"
+	vmRecoveryPointReplicatePath = vmRecoveryPointSinglePath + "/replicate"
 )
 
+// RestoreOptions configures a restore of a recovery point or snapshot into a new vm
+type RestoreOptions struct {
+	// Name of the new vm, required
+	Name string
+
+	// ClusterReference places the new vm on another cluster. Leave empty to restore
+	// on the cluster where the recovery point or snapshot is located.
+	ClusterReference *schema.Reference
+
+	// SubnetReference attaches all NICs of the new vm to this subnet instead of the
+	// subnets of the source vm
+	SubnetReference *schema.Reference
+
+	// DisconnectNics creates the NICs of the new vm in disconnected state
+	DisconnectNics bool
+}
+
 // VMRecoveryPointClient is a client for the vm API.
 type VMRecoveryPointClient struct {
 	client *Client
@@ -67,3 +86,225 @@ func (c *VMRecoveryPointClient) Create(ctx context.Context, createRequest *schem
 func (c *VMRecoveryPointClient) Delete(ctx context.Context, s *schema.VMRecoveryPointIntent) error {
 	return c.client.requestHelper(ctx, fmt.Sprintf(vmRecoveryPointSinglePath, s.Metadata.UUID), http.MethodDelete, nil, nil)
 }
+
+// RestoreAsNewVM materialises the recovery point as a new vm and waits for it to be created.
+// The vm the recovery point was taken of is not modified. If opts.ClusterReference points to
+// another cluster, a replica of the recovery point located on that cluster is restored.
+func (c *VMRecoveryPointClient) RestoreAsNewVM(ctx context.Context, rp *schema.VMRecoveryPointIntent, opts *RestoreOptions) (*schema.VMIntent, error) {
+	if opts == nil || opts.Name == "" {
+		return nil, fmt.Errorf("a name for the new vm is required")
+	}
+	if opts.ClusterReference != nil && (rp.Status == nil || rp.Status.ClusterReference == nil || rp.Status.ClusterReference.UUID != opts.ClusterReference.UUID) {
+		replica, err := c.findReplica(ctx, rp, opts.ClusterReference.UUID)
+		if err != nil {
+			return nil, err
+		}
+		rp = replica
+	}
+
+	restoreRequest := &schema.VMRecoveryPointRestoreRequest{
+		VMOverrideResources: &schema.VMOverrideResources{
+			Name: opts.Name,
+		},
+	}
+
+	if opts.SubnetReference != nil || opts.DisconnectNics {
+		if rp.Status == nil || rp.Status.Resources == nil || rp.Status.Resources.VMSpec == nil || rp.Status.Resources.VMSpec.Resources == nil {
+			return nil, fmt.Errorf("recovery point %s has no vm spec to override NICs", rp.Metadata.UUID)
+		}
+		for _, n := range rp.Status.Resources.VMSpec.Resources.NicList {
+			nic := &schema.VMOverrideNic{
+				IsConnected:     !opts.DisconnectNics,
+				Model:           n.Model,
+				NicType:         n.NicType,
+				SubnetReference: n.SubnetReference,
+			}
+			if opts.SubnetReference != nil {
+				nic.SubnetReference = opts.SubnetReference
+			}
+			restoreRequest.VMOverrideResources.NicList = append(restoreRequest.VMOverrideResources.NicList, nic)
+		}
+	}
+
+	response := new(schema.ExecutionContext)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(vmRecoveryPointRestorePath, rp.Metadata.UUID), http.MethodPost, restoreRequest, response)
+	if err != nil {
+		return nil, err
+	}
+
+	taskUUID := response.GetTaskUUID()
+	if taskUUID == "" {
+		return nil, fmt.Errorf("restore of recovery point %s returned no task", rp.Metadata.UUID)
+	}
+	task, err := c.client.Task.Wait(ctx, taskUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var parentVMUUID string
+	if rp.Status != nil && rp.Status.Resources != nil && rp.Status.Resources.ParentVMReference != nil {
+		parentVMUUID = rp.Status.Resources.ParentVMReference.UUID
+	}
+	vmUUID := entityUUIDFromTask(task, "vm", parentVMUUID)
+	if vmUUID == "" {
+		return nil, fmt.Errorf("restore task %s did not reference the new vm", utils.StringValue(task.UUID))
+	}
+	return c.client.VM.GetByUUID(ctx, vmUUID)
+}
+
+// listAll pages through all recovery points
+func (c *VMRecoveryPointClient) listAll(ctx context.Context) ([]*schema.VMRecoveryPointIntent, error) {
+	var recoveryPoints []*schema.VMRecoveryPointIntent
+	var offset int64
+	for {
+		list, err := c.List(ctx, &schema.DSMetadata{Length: utils.Int64Ptr(itemsPerPage), Offset: utils.Int64Ptr(offset)})
+		if err != nil {
+			return nil, err
+		}
+		recoveryPoints = append(recoveryPoints, list.Entities...)
+		offset += int64(len(list.Entities))
+		if len(list.Entities) == 0 || list.Metadata == nil || offset >= list.Metadata.TotalMatches {
+			return recoveryPoints, nil
+		}
+	}
+}
+
+// findReplica returns the instance of the recovery point located on the given cluster
+func (c *VMRecoveryPointClient) findReplica(ctx context.Context, rp *schema.VMRecoveryPointIntent, clusterUUID string) (*schema.VMRecoveryPointIntent, error) {
+	if rp.Status == nil || rp.Status.Resources == nil || rp.Status.Resources.VMRecoveryPointLocationAgnosticUUID == nil {
+		return nil, fmt.Errorf("recovery point %s has no location agnostic uuid", rp.Metadata.UUID)
+	}
+	locationAgnosticUUID := *rp.Status.Resources.VMRecoveryPointLocationAgnosticUUID
+
+	replicas, err := c.listAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, replica := range replicas {
+		if replica.Status == nil || replica.Status.Resources == nil || replica.Status.ClusterReference == nil {
+			continue
+		}
+		if utils.StringValue(replica.Status.Resources.VMRecoveryPointLocationAgnosticUUID) == locationAgnosticUUID &&
+			replica.Status.ClusterReference.UUID == clusterUUID {
+			return replica, nil
+		}
+	}
+	return nil, fmt.Errorf("recovery point %s has no replica on cluster %s", rp.Metadata.UUID, clusterUUID)
+}
+
+// Replicate replicates the recovery point to the target availability zone and cluster, waits
+// for the replication task and returns the replica found by its location agnostic uuid.
+func (c *VMRecoveryPointClient) Replicate(ctx context.Context, rp *schema.VMRecoveryPointIntent, targetAZ, targetCluster *schema.Reference) (*schema.VMRecoveryPointIntent, error) {
+	if targetCluster == nil {
+		return nil, fmt.Errorf("target cluster is required")
+	}
+	replicateRequest := &schema.VMRecoveryPointReplicateRequest{
+		TargetAvailabilityZoneReference: targetAZ,
+		TargetClusterReference:          targetCluster,
+	}
+
+	response := new(schema.ExecutionContext)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(vmRecoveryPointReplicatePath, rp.Metadata.UUID), http.MethodPost, replicateRequest, response)
+	if err != nil {
+		return nil, err
+	}
+
+	taskUUID := response.GetTaskUUID()
+	if taskUUID == "" {
+		return nil, fmt.Errorf("replication of recovery point %s returned no task", rp.Metadata.UUID)
+	}
+	if _, err = c.client.Task.Wait(ctx, taskUUID); err != nil {
+		return nil, err
+	}
+	return c.findReplica(ctx, rp, targetCluster.UUID)
+}
+
+// ListReplicas returns the recovery points matching opts grouped by their location agnostic
+// uuid. All instances of a replicated recovery point share the same group.
+func (c *VMRecoveryPointClient) ListReplicas(ctx context.Context, opts *schema.DSMetadata) (map[string][]*schema.VMRecoveryPointIntent, error) {
+	list, err := c.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	replicas := make(map[string][]*schema.VMRecoveryPointIntent)
+	for _, rp := range list.Entities {
+		var locationAgnosticUUID string
+		if rp.Status != nil && rp.Status.Resources != nil {
+			locationAgnosticUUID = utils.StringValue(rp.Status.Resources.VMRecoveryPointLocationAgnosticUUID)
+		}
+		if locationAgnosticUUID == "" && rp.Spec != nil && rp.Spec.Resources != nil {
+			locationAgnosticUUID = rp.Spec.Resources.VMRecoveryPointLocationAgnosticUUID
+		}
+		if locationAgnosticUUID == "" {
+			continue
+		}
+		replicas[locationAgnosticUUID] = append(replicas[locationAgnosticUUID], rp)
+	}
+	return replicas, nil
+}
+
+// DeleteAndWait deletes a VMRecoveryPoint and waits for the delete task to complete
+func (c *VMRecoveryPointClient) DeleteAndWait(ctx context.Context, s *schema.VMRecoveryPointIntent) error {
+	response := new(schema.DeleteResponse)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(vmRecoveryPointSinglePath, s.Metadata.UUID), http.MethodDelete, nil, response)
+	if err != nil {
+		return err
+	}
+	if response.Status == nil {
+		return nil
+	}
+	_, err = c.client.Task.Wait(ctx, response.Status.ExecutionContext.GetTaskUUID())
+	return err
+}
+
+// CreateApplicationConsistent creates an application consistent recovery point of the vm and
+// waits for it to be created. Nutanix Guest Tools must be enabled, VSS snapshot capable and
+// reachable on the vm. If they are not, the recovery point is created crash consistent when
+// fallback is set, otherwise an error wrapping ErrNGTNotReady is returned.
+// The returned string is the consistency level the recovery point was actually created with.
+func (c *VMRecoveryPointClient) CreateApplicationConsistent(ctx context.Context, vm *schema.VMIntent, name string, fallback bool) (*schema.VMRecoveryPointIntent, string, error) {
+	vm, err := c.client.VM.GetByUUID(ctx, vm.Metadata.UUID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	recoveryPointType := schema.RecoveryPointTypeApplicationConsistent
+	if err = checkNGTSnapshotReady(vm); err != nil {
+		if !fallback {
+			return nil, "", err
+		}
+		recoveryPointType = schema.RecoveryPointTypeCrashConsistent
+	}
+
+	response, err := c.Create(ctx, &schema.VMRecoveryPointRequest{
+		Metadata: &schema.Metadata{
+			Kind: "vm_recovery_point",
+		},
+		Spec: &schema.VMRecoveryPoint{
+			Name: name,
+			Resources: &schema.VMRecoveryPointResources{
+				ParentVMReference: &schema.Reference{Kind: "vm", UUID: vm.Metadata.UUID},
+				RecoveryPointType: recoveryPointType,
+			},
+		},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if response.Status != nil {
+		if _, err = c.client.Task.Wait(ctx, response.Status.ExecutionContext.GetTaskUUID()); err != nil {
+			return nil, "", err
+		}
+	}
+
+	rp, err := c.GetByUUID(ctx, response.Metadata.UUID)
+	if err != nil {
+		return nil, "", err
+	}
+	// the cluster falls back to crash consistent itself if quiescing the guest fails
+	if rp.Status != nil && rp.Status.Resources != nil && rp.Status.Resources.RecoveryPointType != "" {
+		recoveryPointType = rp.Status.Resources.RecoveryPointType
+	}
+	return rp, recoveryPointType, nil
+}
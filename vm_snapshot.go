@@ -0,0 +1,95 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/pkg/utils"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+const (
+	vmSnapshotBasePath   = "/vm_snapshots"
+	vmSnapshotListPath   = vmSnapshotBasePath + "/list"
+	vmSnapshotSinglePath = vmSnapshotBasePath + "/%s"
+)
+
+// VMSnapshotClient is a client for the v3 vm snapshot API. The v3 API has no restore
+// endpoint for vm snapshots. To restore a vm into a new vm, take a recovery point with
+// VMRecoveryPointClient.Create and restore it with VMRecoveryPointClient.RestoreAsNewVM, or
+// restore a v2 snapshot of the vm with SnapshotClient.RestoreAsNewVM.
+type VMSnapshotClient struct {
+	client *Client
+}
+
+// Get retrieves a vm snapshot by its UUID if the input can be parsed as an uuid, otherwise it
+// retrieves a vm snapshot by its name
+func (c *VMSnapshotClient) Get(ctx context.Context, idOrName string) (*schema.VMSnapshotIntent, error) {
+	if utils.IsValidUUID(idOrName) {
+		return c.GetByUUID(ctx, idOrName)
+	}
+	return c.GetByName(ctx, idOrName)
+}
+
+// GetByUUID retrieves a vm snapshot by its UUID
+func (c *VMSnapshotClient) GetByUUID(ctx context.Context, uuid string) (*schema.VMSnapshotIntent, error) {
+	response := new(schema.VMSnapshotIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(vmSnapshotSinglePath, uuid), http.MethodGet, nil, response)
+	return response, err
+}
+
+// GetByName retrieves a vm snapshot by its name
+func (c *VMSnapshotClient) GetByName(ctx context.Context, name string) (*schema.VMSnapshotIntent, error) {
+	list, err := c.List(ctx, &schema.DSMetadata{Filter: fmt.Sprintf("name==%s", name)})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Entities) == 0 {
+		return nil, fmt.Errorf("vm snapshot not found: %s", name)
+	}
+	return list.Entities[0], err
+}
+
+// List returns a list of vm snapshots for a specific page.
+func (c *VMSnapshotClient) List(ctx context.Context, opts *schema.DSMetadata) (*schema.VMSnapshotListIntent, error) {
+	response := new(schema.VMSnapshotListIntent)
+	err := c.client.requestHelper(ctx, vmSnapshotListPath, http.MethodPost, opts, response)
+	return response, err
+}
+
+// All returns all vm snapshots
+func (c *VMSnapshotClient) All(ctx context.Context) (*schema.VMSnapshotListIntent, error) {
+	return c.List(ctx, &schema.DSMetadata{Length: utils.Int64Ptr(itemsPerPage), Offset: utils.Int64Ptr(0)})
+}
+
+// Create creates a snapshot of the entity with the given uuid. snapshotType is either
+// schema.SnapshotTypeCrashConsistent or schema.SnapshotTypeApplicationConsistent.
+// A zero expiration creates a snapshot which never expires.
+func (c *VMSnapshotClient) Create(ctx context.Context, entityUUID, name, snapshotType string, expiration time.Time) (*schema.VMSnapshotIntent, error) {
+	createRequest := &schema.VMSnapshotIntent{
+		Metadata: &schema.Metadata{
+			Kind: "vm_snapshot",
+		},
+		Spec: &schema.VMSnapshot{
+			Name:         name,
+			SnapshotType: snapshotType,
+			Resources: &schema.VMSnapshotResources{
+				EntityUUID: entityUUID,
+			},
+		},
+	}
+	if !expiration.IsZero() {
+		createRequest.Spec.ExpirationTimeMsecs = utils.TimeUnixMilli(expiration)
+	}
+
+	response := new(schema.VMSnapshotIntent)
+	err := c.client.requestHelper(ctx, vmSnapshotBasePath, http.MethodPost, createRequest, response)
+	return response, err
+}
+
+// Delete deletes a vm snapshot
+func (c *VMSnapshotClient) Delete(ctx context.Context, uuid string) error {
+	return c.client.requestHelper(ctx, fmt.Sprintf(vmSnapshotSinglePath, uuid), http.MethodDelete, nil, nil)
+}
@@ -70,12 +70,37 @@ func (c *VpcClient) Create(ctx context.Context, createRequest *schema.VpcIntent)
 
 // Update a vpc
 func (c *VpcClient) Update(ctx context.Context, vpc *schema.VpcIntent) (*schema.VpcIntent, error) {
-	vpc.Status = nil
 	response := new(schema.VpcIntent)
-	err := c.client.requestHelper(ctx, fmt.Sprintf(vpcSinglePath, vpc.Metadata.UUID), http.MethodPut, vpc, response)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(vpcSinglePath, vpc.Metadata.UUID), http.MethodPut, vpc.ToUpdateRequest(), response)
 	return response, err
 }
 
+// UpdateWith fetches the latest vpc, applies mutate to it and updates it. On a
+// spec_version conflict the vpc is fetched again and mutate is re-applied.
+// It returns the updated vpc and the uuid of the update task.
+func (c *VpcClient) UpdateWith(ctx context.Context, uuid string, mutate func(*schema.VpcIntent) error) (*schema.VpcIntent, string, error) {
+	var response *schema.VpcIntent
+	err := c.client.retryOnConflict(ctx, func() error {
+		vpc, err := c.GetByUUID(ctx, uuid)
+		if err != nil {
+			return err
+		}
+		if err = mutate(vpc); err != nil {
+			return err
+		}
+		response, err = c.Update(ctx, vpc)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	var taskUUID string
+	if response.Status != nil {
+		taskUUID = response.Status.ExecutionContext.GetTaskUUID()
+	}
+	return response, taskUUID, nil
+}
+
 // Delete deletes a vpc
 func (c *VpcClient) Delete(ctx context.Context, uuid string) error {
 	return c.client.requestHelper(ctx, fmt.Sprintf(vpcSinglePath, uuid), http.MethodDelete, nil, nil)
@@ -0,0 +1,185 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+const vpcRouteTablePath = vpcSinglePath + "/route_tables"
+
+// RouteTable retrieves the route table of a vpc
+func (c *VpcClient) RouteTable(ctx context.Context, vpcUUID string) (*schema.VpcRouteTableIntent, error) {
+	response := new(schema.VpcRouteTableIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(vpcRouteTablePath, vpcUUID), http.MethodGet, nil, response)
+	return response, err
+}
+
+// UpdateRouteTable updates the route table of a vpc
+func (c *VpcClient) UpdateRouteTable(ctx context.Context, vpcUUID string, routeTable *schema.VpcRouteTableIntent) (*schema.VpcRouteTableIntent, error) {
+	response := new(schema.VpcRouteTableIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(vpcRouteTablePath, vpcUUID), http.MethodPut, routeTable.ToUpdateRequest(), response)
+	return response, err
+}
+
+// UpdateRouteTableWith fetches the latest route table of the vpc, applies mutate to its spec
+// resources, updates it and waits for the update task. On a spec_version conflict the route
+// table is fetched again and mutate is re-applied.
+func (c *VpcClient) UpdateRouteTableWith(ctx context.Context, vpcUUID string, mutate func(*schema.VpcRouteTableResources) error) (*schema.VpcRouteTableIntent, error) {
+	var response *schema.VpcRouteTableIntent
+	err := c.client.retryOnConflict(ctx, func() error {
+		routeTable, err := c.RouteTable(ctx, vpcUUID)
+		if err != nil {
+			return err
+		}
+		if routeTable.Spec == nil {
+			routeTable.Spec = &schema.VpcRouteTable{}
+		}
+		if routeTable.Spec.Resources == nil {
+			routeTable.Spec.Resources = &schema.VpcRouteTableResources{}
+		}
+		if err = mutate(routeTable.Spec.Resources); err != nil {
+			return err
+		}
+		response, err = c.UpdateRouteTable(ctx, vpcUUID, routeTable)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if response.Status != nil {
+		if _, err = c.client.Task.Wait(ctx, response.Status.ExecutionContext.GetTaskUUID()); err != nil {
+			return nil, err
+		}
+	}
+	return c.RouteTable(ctx, vpcUUID)
+}
+
+// AddStaticRoute adds a static route to destination. An existing static route to the same
+// destination is replaced.
+func (c *VpcClient) AddStaticRoute(ctx context.Context, vpcUUID string, destination *schema.IPSubnet, nexthop *schema.NextHop) (*schema.VpcRouteTableIntent, error) {
+	prefix, err := ipSubnetPrefix(destination)
+	if err != nil {
+		return nil, err
+	}
+	if err = validateNextHop(nexthop); err != nil {
+		return nil, err
+	}
+	return c.UpdateRouteTableWith(ctx, vpcUUID, func(resources *schema.VpcRouteTableResources) error {
+		resources.StaticRoutesList = append(removeStaticRoute(resources.StaticRoutesList, prefix), &schema.StaticRoute{
+			Destination: prefix.String(),
+			Nexthop:     nexthop,
+		})
+		return nil
+	})
+}
+
+// RemoveStaticRoute removes the static route to destination
+func (c *VpcClient) RemoveStaticRoute(ctx context.Context, vpcUUID string, destination *schema.IPSubnet) (*schema.VpcRouteTableIntent, error) {
+	prefix, err := ipSubnetPrefix(destination)
+	if err != nil {
+		return nil, err
+	}
+	return c.UpdateRouteTableWith(ctx, vpcUUID, func(resources *schema.VpcRouteTableResources) error {
+		resources.StaticRoutesList = removeStaticRoute(resources.StaticRoutesList, prefix)
+		return nil
+	})
+}
+
+// SetDefaultRoute routes the egress traffic of the vpc to the external subnet. The external
+// subnet must be attached to the vpc.
+func (c *VpcClient) SetDefaultRoute(ctx context.Context, vpcUUID string, externalSubnet *schema.Reference) (*schema.VpcRouteTableIntent, error) {
+	vpc, err := c.GetByUUID(ctx, vpcUUID)
+	if err != nil {
+		return nil, err
+	}
+	attached := false
+	if vpc.Spec != nil && vpc.Spec.Resources != nil {
+		for _, subnet := range vpc.Spec.Resources.ExternalSubnetList {
+			if subnet.ExternalSubnetReference != nil && subnet.ExternalSubnetReference.UUID == externalSubnet.UUID {
+				attached = true
+			}
+		}
+	}
+	if !attached {
+		return nil, fmt.Errorf("external subnet %s is not attached to vpc %s", externalSubnet.UUID, vpcUUID)
+	}
+
+	return c.UpdateRouteTableWith(ctx, vpcUUID, func(resources *schema.VpcRouteTableResources) error {
+		resources.DefaultRouteNexthop = &schema.NextHop{ExternalSubnetReference: externalSubnet}
+		return nil
+	})
+}
+
+// RemoveDefaultRoute removes the default route of the vpc
+func (c *VpcClient) RemoveDefaultRoute(ctx context.Context, vpcUUID string) (*schema.VpcRouteTableIntent, error) {
+	return c.UpdateRouteTableWith(ctx, vpcUUID, func(resources *schema.VpcRouteTableResources) error {
+		resources.DefaultRouteNexthop = nil
+		return nil
+	})
+}
+
+// ipSubnetPrefix validates an ipv4 or ipv6 prefix. The address must be the network address of the prefix.
+func ipSubnetPrefix(subnet *schema.IPSubnet) (*net.IPNet, error) {
+	if subnet == nil {
+		return nil, fmt.Errorf("ip subnet is required")
+	}
+	ip := net.ParseIP(subnet.IP)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid ip address: %q", subnet.IP)
+	}
+	bits := 8 * net.IPv6len
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, bits = ip4, 8*net.IPv4len
+	}
+	if subnet.PrefixLength < 0 || int(subnet.PrefixLength) > bits {
+		return nil, fmt.Errorf("invalid prefix length %d of %s", subnet.PrefixLength, subnet.IP)
+	}
+	prefix := &net.IPNet{IP: ip, Mask: net.CIDRMask(int(subnet.PrefixLength), bits)}
+	if !ip.Mask(prefix.Mask).Equal(ip) {
+		return nil, fmt.Errorf("%s/%d is not a network address, did you mean %s", subnet.IP, subnet.PrefixLength, &net.IPNet{IP: ip.Mask(prefix.Mask), Mask: prefix.Mask})
+	}
+	return prefix, nil
+}
+
+// validateNextHop verifies exactly one next hop reference with an uuid is set
+func validateNextHop(nexthop *schema.NextHop) error {
+	if nexthop == nil {
+		return fmt.Errorf("next hop is required")
+	}
+	var refs []*schema.Reference
+	for _, ref := range []*schema.Reference{
+		nexthop.ExternalSubnetReference,
+		nexthop.LocalSubnetReference,
+		nexthop.VpnConnectionReference,
+		nexthop.DirectConnectVirtualInterfaceReference,
+		nexthop.VtepGatewayReference,
+	} {
+		if ref != nil {
+			refs = append(refs, ref)
+		}
+	}
+	if len(refs) != 1 {
+		return fmt.Errorf("next hop must reference exactly one target, got %d", len(refs))
+	}
+	if refs[0].UUID == "" {
+		return fmt.Errorf("next hop reference has no uuid")
+	}
+	return nil
+}
+
+// removeStaticRoute returns routes without the routes to prefix
+func removeStaticRoute(routes []*schema.StaticRoute, prefix *net.IPNet) []*schema.StaticRoute {
+	result := make([]*schema.StaticRoute, 0, len(routes))
+	for _, route := range routes {
+		_, destination, err := net.ParseCIDR(route.Destination)
+		if err == nil && destination.String() == prefix.String() {
+			continue
+		}
+		result = append(result, route)
+	}
+	return result
+}
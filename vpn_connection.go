@@ -0,0 +1,179 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/pkg/utils"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+const (
+	vpnConnectionBasePath   = "/vpn_connections"
+	vpnConnectionListPath   = vpnConnectionBasePath + "/list"
+	vpnConnectionSinglePath = vpnConnectionBasePath + "/%s"
+)
+
+// VpnConnectionClient is a client for the vpn connection API.
+type VpnConnectionClient struct {
+	client *Client
+}
+
+// Get retrieves a vpn connection by its UUID if the input can be parsed as an uuid, otherwise it
+// retrieves a vpn connection by its name
+func (c *VpnConnectionClient) Get(ctx context.Context, idOrName string) (*schema.VpnConnectionIntent, error) {
+	if utils.IsValidUUID(idOrName) {
+		return c.GetByUUID(ctx, idOrName)
+	}
+	return c.GetByName(ctx, idOrName)
+}
+
+// GetByUUID retrieves a vpn connection by its UUID
+func (c *VpnConnectionClient) GetByUUID(ctx context.Context, uuid string) (*schema.VpnConnectionIntent, error) {
+	response := new(schema.VpnConnectionIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(vpnConnectionSinglePath, uuid), http.MethodGet, nil, response)
+	return response, err
+}
+
+// GetByName retrieves a vpn connection by its name
+func (c *VpnConnectionClient) GetByName(ctx context.Context, name string) (*schema.VpnConnectionIntent, error) {
+	list, err := c.List(ctx, &schema.DSMetadata{Filter: fmt.Sprintf("name==%s", name)})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Entities) == 0 {
+		return nil, fmt.Errorf("vpn connection not found: %s", name)
+	}
+	return list.Entities[0], err
+}
+
+// List returns a list of vpn connections
+func (c *VpnConnectionClient) List(ctx context.Context, opts *schema.DSMetadata) (*schema.VpnConnectionListIntent, error) {
+	response := new(schema.VpnConnectionListIntent)
+	err := c.client.requestHelper(ctx, vpnConnectionListPath, http.MethodPost, opts, response)
+	return response, err
+
+}
+
+// All returns all vpn connections
+func (c *VpnConnectionClient) All(ctx context.Context) (*schema.VpnConnectionListIntent, error) {
+	return c.List(ctx, &schema.DSMetadata{Length: utils.Int64Ptr(itemsPerPage), Offset: utils.Int64Ptr(0)})
+}
+
+// Create creates a vpn connection
+func (c *VpnConnectionClient) Create(ctx context.Context, createRequest *schema.VpnConnectionIntent) (*schema.VpnConnectionIntent, error) {
+	response := new(schema.VpnConnectionIntent)
+	err := c.client.requestHelper(ctx, vpnConnectionBasePath, http.MethodPost, createRequest, response)
+	return response, err
+}
+
+// Update a vpn connection
+func (c *VpnConnectionClient) Update(ctx context.Context, connection *schema.VpnConnectionIntent) (*schema.VpnConnectionIntent, error) {
+	response := new(schema.VpnConnectionIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(vpnConnectionSinglePath, connection.Metadata.UUID), http.MethodPut, connection.ToUpdateRequest(), response)
+	return response, err
+}
+
+// UpdateWith fetches the latest vpn connection, applies mutate to it and updates it. On a
+// spec_version conflict the vpn connection is fetched again and mutate is re-applied.
+// It returns the updated vpn connection and the uuid of the update task.
+func (c *VpnConnectionClient) UpdateWith(ctx context.Context, uuid string, mutate func(*schema.VpnConnectionIntent) error) (*schema.VpnConnectionIntent, string, error) {
+	var response *schema.VpnConnectionIntent
+	err := c.client.retryOnConflict(ctx, func() error {
+		connection, err := c.GetByUUID(ctx, uuid)
+		if err != nil {
+			return err
+		}
+		if err = mutate(connection); err != nil {
+			return err
+		}
+		response, err = c.Update(ctx, connection)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	var taskUUID string
+	if response.Status != nil {
+		taskUUID = response.Status.ExecutionContext.GetTaskUUID()
+	}
+	return response, taskUUID, nil
+}
+
+// Delete deletes a vpn connection
+func (c *VpnConnectionClient) Delete(ctx context.Context, uuid string) error {
+	return c.client.requestHelper(ctx, fmt.Sprintf(vpnConnectionSinglePath, uuid), http.MethodDelete, nil, nil)
+}
+
+// VpnConnectionStatus is the operational status of a vpn connection
+type VpnConnectionStatus struct {
+	IpsecStatus string
+
+	// BgpSessionStatus is empty if the gateways use static routing
+	BgpSessionStatus string
+}
+
+// IsUp returns true if the IPsec tunnel and, with BGP routing, the BGP session are up
+func (s *VpnConnectionStatus) IsUp() bool {
+	return s.IpsecStatus == schema.VpnStatusUp && (s.BgpSessionStatus == "" || s.BgpSessionStatus == schema.VpnStatusUp)
+}
+
+// Status returns the operational status of a vpn connection
+func (c *VpnConnectionClient) Status(ctx context.Context, uuid string) (*VpnConnectionStatus, error) {
+	connection, err := c.GetByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	status := &VpnConnectionStatus{}
+	if connection.Status != nil && connection.Status.Resources != nil {
+		status.IpsecStatus = connection.Status.Resources.IpsecStatus
+		status.BgpSessionStatus = connection.Status.Resources.BgpSessionStatus
+	}
+	return status, nil
+}
+
+// WaitUntilUp polls a vpn connection until it is up or ctx is done
+func (c *VpnConnectionClient) WaitUntilUp(ctx context.Context, uuid string) (*VpnConnectionStatus, error) {
+	ticker := time.NewTicker(taskPollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := c.Status(ctx, uuid)
+		if err != nil {
+			return nil, err
+		}
+		if status.IsUp() {
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// SetDynamicRoutePriority sets the priority of the routes learned over the vpn connection and
+// waits for the update task
+func (c *VpnConnectionClient) SetDynamicRoutePriority(ctx context.Context, uuid string, priority int64) (*schema.VpnConnectionIntent, error) {
+	if priority <= 0 {
+		return nil, fmt.Errorf("invalid dynamic route priority %d", priority)
+	}
+	_, taskUUID, err := c.UpdateWith(ctx, uuid, func(connection *schema.VpnConnectionIntent) error {
+		if connection.Spec == nil || connection.Spec.Resources == nil {
+			return fmt.Errorf("vpn connection %s has no spec resources", uuid)
+		}
+		connection.Spec.Resources.DynamicRoutePriority = utils.Int64Ptr(priority)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = c.client.Task.Wait(ctx, taskUUID); err != nil {
+		return nil, err
+	}
+	return c.GetByUUID(ctx, uuid)
+}
@@ -0,0 +1,152 @@
+package nutanix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/tecbiz-ch/nutanix-go-sdk/pkg/utils"
+	"github.com/tecbiz-ch/nutanix-go-sdk/schema"
+)
+
+const (
+	vpnGatewayBasePath   = "/vpn_gateways"
+	vpnGatewayListPath   = vpnGatewayBasePath + "/list"
+	vpnGatewaySinglePath = vpnGatewayBasePath + "/%s"
+)
+
+// VpnGatewayClient is a client for the vpn gateway API.
+type VpnGatewayClient struct {
+	client *Client
+}
+
+// Get retrieves a vpn gateway by its UUID if the input can be parsed as an uuid, otherwise it
+// retrieves a vpn gateway by its name
+func (c *VpnGatewayClient) Get(ctx context.Context, idOrName string) (*schema.VpnGatewayIntent, error) {
+	if utils.IsValidUUID(idOrName) {
+		return c.GetByUUID(ctx, idOrName)
+	}
+	return c.GetByName(ctx, idOrName)
+}
+
+// GetByUUID retrieves a vpn gateway by its UUID
+func (c *VpnGatewayClient) GetByUUID(ctx context.Context, uuid string) (*schema.VpnGatewayIntent, error) {
+	response := new(schema.VpnGatewayIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(vpnGatewaySinglePath, uuid), http.MethodGet, nil, response)
+	return response, err
+}
+
+// GetByName retrieves a vpn gateway by its name
+func (c *VpnGatewayClient) GetByName(ctx context.Context, name string) (*schema.VpnGatewayIntent, error) {
+	list, err := c.List(ctx, &schema.DSMetadata{Filter: fmt.Sprintf("name==%s", name)})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Entities) == 0 {
+		return nil, fmt.Errorf("vpn gateway not found: %s", name)
+	}
+	return list.Entities[0], err
+}
+
+// List returns a list of vpn gateways
+func (c *VpnGatewayClient) List(ctx context.Context, opts *schema.DSMetadata) (*schema.VpnGatewayListIntent, error) {
+	response := new(schema.VpnGatewayListIntent)
+	err := c.client.requestHelper(ctx, vpnGatewayListPath, http.MethodPost, opts, response)
+	return response, err
+
+}
+
+// All returns all vpn gateways
+func (c *VpnGatewayClient) All(ctx context.Context) (*schema.VpnGatewayListIntent, error) {
+	return c.List(ctx, &schema.DSMetadata{Length: utils.Int64Ptr(itemsPerPage), Offset: utils.Int64Ptr(0)})
+}
+
+// Create creates a vpn gateway. Local gateways require a vpc, remote gateways an ip address.
+func (c *VpnGatewayClient) Create(ctx context.Context, createRequest *schema.VpnGatewayIntent) (*schema.VpnGatewayIntent, error) {
+	if err := validateVpnGateway(createRequest); err != nil {
+		return nil, err
+	}
+	response := new(schema.VpnGatewayIntent)
+	err := c.client.requestHelper(ctx, vpnGatewayBasePath, http.MethodPost, createRequest, response)
+	return response, err
+}
+
+// Update a vpn gateway
+func (c *VpnGatewayClient) Update(ctx context.Context, gateway *schema.VpnGatewayIntent) (*schema.VpnGatewayIntent, error) {
+	response := new(schema.VpnGatewayIntent)
+	err := c.client.requestHelper(ctx, fmt.Sprintf(vpnGatewaySinglePath, gateway.Metadata.UUID), http.MethodPut, gateway.ToUpdateRequest(), response)
+	return response, err
+}
+
+// UpdateWith fetches the latest vpn gateway, applies mutate to it and updates it. On a
+// spec_version conflict the vpn gateway is fetched again and mutate is re-applied.
+// It returns the updated vpn gateway and the uuid of the update task.
+func (c *VpnGatewayClient) UpdateWith(ctx context.Context, uuid string, mutate func(*schema.VpnGatewayIntent) error) (*schema.VpnGatewayIntent, string, error) {
+	var response *schema.VpnGatewayIntent
+	err := c.client.retryOnConflict(ctx, func() error {
+		gateway, err := c.GetByUUID(ctx, uuid)
+		if err != nil {
+			return err
+		}
+		if err = mutate(gateway); err != nil {
+			return err
+		}
+		response, err = c.Update(ctx, gateway)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	var taskUUID string
+	if response.Status != nil {
+		taskUUID = response.Status.ExecutionContext.GetTaskUUID()
+	}
+	return response, taskUUID, nil
+}
+
+// Delete deletes a vpn gateway
+func (c *VpnGatewayClient) Delete(ctx context.Context, uuid string) error {
+	return c.client.requestHelper(ctx, fmt.Sprintf(vpnGatewaySinglePath, uuid), http.MethodDelete, nil, nil)
+}
+
+// validateVpnGateway verifies the gateway type and the routing protocol configuration
+func validateVpnGateway(gateway *schema.VpnGatewayIntent) error {
+	if gateway.Spec == nil || gateway.Spec.Resources == nil {
+		return fmt.Errorf("vpn gateway has no spec resources")
+	}
+	resources := gateway.Spec.Resources
+	switch resources.GatewayType {
+	case schema.VpnGatewayTypeLocal:
+		if resources.VpcReference == nil {
+			return fmt.Errorf("local vpn gateway %s requires a vpc reference", gateway.Spec.Name)
+		}
+	case schema.VpnGatewayTypeRemote:
+		if resources.IPAddress == "" {
+			return fmt.Errorf("remote vpn gateway %s requires an ip address", gateway.Spec.Name)
+		}
+	default:
+		return fmt.Errorf("invalid vpn gateway type: %q", resources.GatewayType)
+	}
+
+	routing := resources.RoutingProtocolConfig
+	if routing == nil {
+		return fmt.Errorf("vpn gateway %s has no routing protocol config", gateway.Spec.Name)
+	}
+	switch routing.RoutingProtocol {
+	case schema.VpnRoutingProtocolBGP:
+		if routing.BgpConfig == nil || routing.BgpConfig.Asn <= 0 {
+			return fmt.Errorf("vpn gateway %s requires a bgp asn", gateway.Spec.Name)
+		}
+	case schema.VpnRoutingProtocolStatic:
+		if routing.StaticRouteConfig != nil {
+			for _, prefix := range routing.StaticRouteConfig.PrefixList {
+				if _, err := ipSubnetPrefix(prefix); err != nil {
+					return err
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("invalid routing protocol: %q", routing.RoutingProtocol)
+	}
+	return nil
+}